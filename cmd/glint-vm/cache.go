@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/urfave/cli/v3"
-	"github.com/youkoulayley/glint-vm/internal/config"
 	"github.com/youkoulayley/glint-vm/internal/downloader"
 )
 
@@ -13,28 +12,27 @@ const (
 	kilobyte = 1024
 )
 
-// cacheListCommand lists all cached versions.
-func cacheListCommand(_ context.Context, _ *cli.Command) error {
-	cacheManager, err := downloader.NewCacheManager()
+// cacheListCommand lists all cached versions, of the host or of the platform selected with --platform.
+func cacheListCommand(_ context.Context, cmd *cli.Command) error {
+	cfg, err := targetConfig(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to initialize cache manager: %w", err)
+		return err
 	}
 
+	cacheManager := downloader.NewCacheManagerFor(cfg)
+
 	versions, err := cacheManager.List()
 	if err != nil {
 		return fmt.Errorf("failed to list versions: %w", err)
 	}
 
-	cfg, err := config.New()
-	if err != nil {
-		return fmt.Errorf("failed to initialize config: %w", err)
-	}
-
 	fmt.Printf("Cache directory: %s\n", cfg.GetVersionsDir())
 
 	if len(versions) == 0 {
 		fmt.Println("No cached versions found.")
 
+		printOtherPlatforms(cfg)
+
 		return nil
 	}
 
@@ -62,16 +60,20 @@ func cacheListCommand(_ context.Context, _ *cli.Command) error {
 		fmt.Printf("  %s %s%s%s\n", status, version.Version, sizeStr, extra)
 	}
 
+	printOtherPlatforms(cfg)
+
 	return nil
 }
 
-// cacheCleanCommand removes old cached versions.
+// cacheCleanCommand removes old cached versions, of the host or of the platform selected with --platform.
 func cacheCleanCommand(_ context.Context, cmd *cli.Command) error {
-	cacheManager, err := downloader.NewCacheManager()
+	cfg, err := targetConfig(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to initialize cache manager: %w", err)
+		return err
 	}
 
+	cacheManager := downloader.NewCacheManagerFor(cfg)
+
 	if cmd.Bool("all") {
 		fmt.Println("Removing all cached versions...")
 
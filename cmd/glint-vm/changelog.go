@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/detector"
+	"github.com/youkoulayley/glint-vm/internal/downloader"
+)
+
+// Changelog output formats.
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+// changelogEntry is a release in the JSON changelog.
+type changelogEntry struct {
+	Version     string    `json:"version"`
+	PublishedAt time.Time `json:"published_at,omitzero"`
+	URL         string    `json:"url,omitempty"`
+	Body        string    `json:"body"`
+}
+
+// changelogCommand prints the release notes of the versions between two versions.
+// Without arguments, it compares the detected project version with the latest stable release;
+// with a single one, the detected version with the given one.
+func changelogCommand(ctx context.Context, cmd *cli.Command) error {
+	format := cmd.String("format")
+	if format != formatMarkdown && format != formatJSON {
+		return fmt.Errorf("%w: %q (want %s or %s)", ErrInvalidFormat, format, formatMarkdown, formatJSON)
+	}
+
+	opts, err := downloaderOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	dl, err := downloader.NewDownloader(opts)
+	if err != nil {
+		return fmt.Errorf("failed to initialize downloader: %w", err)
+	}
+
+	from, to, err := changelogRange(ctx, dl, cmd.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	releases, err := dl.Changelog(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to fetch changelog: %w", err)
+	}
+
+	return writeChangelog(os.Stdout, format, from, to, releases)
+}
+
+// changelogRange resolves the versions compared by the changelog from its arguments.
+func changelogRange(ctx context.Context, dl *downloader.Downloader, args []string) (string, string, error) {
+	if len(args) > 2 {
+		return "", "", fmt.Errorf("%w: expected at most 2 versions, got %d", ErrTooManyArguments, len(args))
+	}
+
+	to := downloader.LatestVersion
+	if len(args) > 0 {
+		to = args[len(args)-1]
+	}
+
+	var from string
+
+	if len(args) == 2 {
+		from = args[0]
+	} else {
+		result, err := detector.QuickDetect()
+		if err != nil {
+			return "", "", fmt.Errorf("detection failed: %w", err)
+		}
+
+		if result == nil {
+			return "", "", ErrVersionRequired
+		}
+
+		from = result.Version
+	}
+
+	from, err := dl.ResolveVersion(ctx, from)
+	if err != nil {
+		return "", "", err
+	}
+
+	to, err = dl.ResolveVersion(ctx, to)
+	if err != nil {
+		return "", "", err
+	}
+
+	return from, to, nil
+}
+
+// writeChangelog writes the release notes of releases in the given format.
+func writeChangelog(w io.Writer, format, from, to string, releases []downloader.Release) error {
+	if format == formatJSON {
+		entries := make([]changelogEntry, 0, len(releases))
+		for _, release := range releases {
+			entries = append(entries, changelogEntry{
+				Version:     release.TagName,
+				PublishedAt: release.PublishedAt,
+				URL:         release.HTMLURL,
+				Body:        release.Body,
+			})
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(entries); err != nil {
+			return fmt.Errorf("failed to encode changelog: %w", err)
+		}
+
+		return nil
+	}
+
+	fmt.Fprintf(w, "# golangci-lint %s → %s\n", from, to)
+
+	if len(releases) == 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "No releases in this range.")
+
+		return nil
+	}
+
+	for _, release := range releases {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s", release.TagName)
+
+		if !release.PublishedAt.IsZero() {
+			fmt.Fprintf(w, " (%s)", release.PublishedAt.Local().Format(sinceLayout))
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w)
+
+		body := strings.TrimSpace(strings.ReplaceAll(release.Body, "\r\n", "\n"))
+		if body == "" {
+			body = "_No release notes._"
+		}
+
+		fmt.Fprintln(w, body)
+
+		if release.HTMLURL != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, release.HTMLURL)
+		}
+	}
+
+	return nil
+}
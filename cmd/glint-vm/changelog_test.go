@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/downloader"
+)
+
+// newChangelogApp returns an app running changelog.
+func newChangelogApp() *cli.Command {
+	return &cli.Command{
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: "mirror"},
+		},
+		Commands: []*cli.Command{
+			{
+				Name: "changelog",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "format", Value: formatMarkdown},
+				},
+				Action: changelogCommand,
+			},
+		},
+	}
+}
+
+func TestChangelogCommand(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	t.Chdir(tmpDir)
+
+	releases := []downloader.Release{
+		{TagName: "v1.64.8", Body: "Fix the cache.", PublishedAt: time.Date(2025, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{TagName: "v1.64.7", Body: "Add a linter."},
+		{TagName: "v1.64.6"},
+	}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(releases)
+	}))
+	defer api.Close()
+
+	mirror := api.URL + ";api=" + api.URL
+
+	var err error
+
+	// Detected version to latest
+	err = newChangelogApp().Run(context.Background(), []string{"glint-vm", "--mirror", mirror, "changelog"})
+	if !errors.Is(err, ErrVersionRequired) {
+		t.Fatalf("changelog without a detected version error = %v, want %v", err, ErrVersionRequired)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, ".golangci-lint.version"), []byte("v1.64.6\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write version file: %v", err)
+	}
+
+	output := captureOutput(func() {
+		err = newChangelogApp().Run(context.Background(), []string{"glint-vm", "--mirror", mirror, "changelog"})
+	})
+	if err != nil {
+		t.Fatalf("changelog failed: %v", err)
+	}
+
+	if !strings.Contains(output, "# golangci-lint v1.64.6 → v1.64.8") ||
+		strings.Index(output, "## v1.64.7") > strings.Index(output, "## v1.64.8 (2025-03-17)") ||
+		!strings.Contains(output, "Fix the cache.") {
+		t.Errorf("changelog should list v1.64.7 then v1.64.8, got: %s", output)
+	}
+
+	output = captureOutput(func() {
+		err = newChangelogApp().Run(context.Background(), []string{
+			"glint-vm", "--mirror", mirror, "changelog", "--format", "json", "v1.64.6", "v1.64.7",
+		})
+	})
+	if err != nil {
+		t.Fatalf("changelog --format json failed: %v", err)
+	}
+
+	var entries []changelogEntry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("changelog --format json output is not JSON: %v\n%s", err, output)
+	}
+
+	if len(entries) != 1 || entries[0].Version != "v1.64.7" || entries[0].Body != "Add a linter." {
+		t.Errorf("changelog --format json = %+v, want v1.64.7 only", entries)
+	}
+}
+
+func TestChangelogCommand_InvalidFormat(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	_, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	err := newChangelogApp().Run(context.Background(), []string{"glint-vm", "changelog", "--format", "html", "v1.0.0", "v1.1.0"})
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("changelog error = %v, want %v", err, ErrInvalidFormat)
+	}
+}
+
+func TestWriteChangelog_Markdown(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	err := writeChangelog(&buf, formatMarkdown, "v1.0.0", "v1.0.1", []downloader.Release{
+		{TagName: "v1.0.1", HTMLURL: "https://example.com/v1.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("writeChangelog() failed: %v", err)
+	}
+
+	want := "# golangci-lint v1.0.0 → v1.0.1\n\n## v1.0.1\n\n_No release notes._\n\nhttps://example.com/v1.0.1\n"
+	if buf.String() != want {
+		t.Errorf("writeChangelog() = %q, want %q", buf.String(), want)
+	}
+}
@@ -36,5 +36,9 @@ func currentCommand(_ context.Context, _ *cli.Command) error {
 		fmt.Printf("Binary path: %s\n", binaryPath)
 	}
 
+	if note := installNote(cfg.GetVersionInfo(version)); note != "" {
+		fmt.Printf("Installed: %s\n", note)
+	}
+
 	return nil
 }
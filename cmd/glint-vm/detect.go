@@ -8,8 +8,6 @@ import (
 	"github.com/urfave/cli/v3"
 	"github.com/youkoulayley/glint-vm/internal/config"
 	"github.com/youkoulayley/glint-vm/internal/detector"
-	"github.com/youkoulayley/glint-vm/internal/downloader"
-	"github.com/youkoulayley/glint-vm/internal/shell"
 )
 
 // detectCommand shows the detected version and source.
@@ -52,36 +50,22 @@ func detectCommand(ctx context.Context, cmd *cli.Command) error {
 	version := result.Version
 
 	if cmd.Bool("use") {
-		dl, err := downloader.NewDownloader()
+		dl, err := newDownloader(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to initialize downloader: %w", err)
+			return err
 		}
 
 		if err = dl.Download(ctx, version); err != nil {
 			return fmt.Errorf("download failed: %w", err)
 		}
 
-		if err = cfg.SetCurrentVersion(version); err != nil {
-			return fmt.Errorf("failed to set current version: %w", err)
-		}
-
-		shellName := shell.DetectShell()
-
-		integrator, err := shell.NewIntegrator(shellName)
-		if err != nil {
-			return fmt.Errorf("failed to create shell integrator: %w", err)
-		}
-
-		output := integrator.GenerateUse(version)
-		fmt.Print(output)
-
-		return nil
+		return activateVersion(ctx, cfg, version)
 	}
 
 	if cmd.Bool("install") {
-		dl, err := downloader.NewDownloader()
+		dl, err := newDownloader(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to initialize downloader: %w", err)
+			return err
 		}
 
 		if err = dl.Download(ctx, version); err != nil {
@@ -122,6 +106,10 @@ func detectCommand(ctx context.Context, cmd *cli.Command) error {
 
 	if cfg.BinaryExists(version) {
 		fmt.Printf("✓ Binary cached at: %s\n", cfg.GetBinaryPath(version))
+
+		if note := installNote(cfg.GetVersionInfo(version)); note != "" {
+			fmt.Printf("  Installed: %s\n", note)
+		}
 	} else {
 		fmt.Printf("⚠ Binary not cached. Run 'glint-vm install %s' to download it.\n", version)
 	}
@@ -6,4 +6,22 @@ import "errors"
 var (
 	// ErrVersionRequired is returned when a version argument is required but not provided.
 	ErrVersionRequired = errors.New("version argument required")
+
+	// ErrUseSingleVersion is returned when --use is combined with several versions.
+	ErrUseSingleVersion = errors.New("--use requires a single version")
+
+	// ErrInstallFailed is returned when some versions of a multi-version install failed.
+	ErrInstallFailed = errors.New("install failed")
+
+	// ErrInvalidMajor is returned when --major is negative.
+	ErrInvalidMajor = errors.New("invalid major version")
+
+	// ErrInvalidSince is returned when --since is not a date.
+	ErrInvalidSince = errors.New("invalid date")
+
+	// ErrInvalidFormat is returned when --format is not a supported output format.
+	ErrInvalidFormat = errors.New("invalid format")
+
+	// ErrTooManyArguments is returned when a command gets more arguments than it accepts.
+	ErrTooManyArguments = errors.New("too many arguments")
 )
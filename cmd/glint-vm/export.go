@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/detector"
+)
+
+// exportCommand copies the binary of a version, for the host or another platform, out of the cache.
+// The version defaults to the detected one.
+func exportCommand(ctx context.Context, cmd *cli.Command) error {
+	dl, err := newDownloader(cmd)
+	if err != nil {
+		return err
+	}
+
+	version, err := dl.ResolveVersion(ctx, cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	if version == "" {
+		result, err := detector.QuickDetect()
+		if err != nil {
+			return fmt.Errorf("detection failed: %w", err)
+		}
+
+		if result == nil {
+			return ErrVersionRequired
+		}
+
+		version = result.Version
+	}
+
+	target, err := dl.Export(ctx, version, cmd.String("dest"))
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Exported golangci-lint %s to %s\n", version, target)
+
+	return nil
+}
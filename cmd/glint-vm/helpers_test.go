@@ -2,10 +2,67 @@ package main
 
 import (
 	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
+func TestMain(m *testing.M) {
+	// Installed as a fake golangci-lint, answer the --version check with the version of its
+	// closest parent directory named after one, as in staging/<version>/extract/ or versions/<version>/
+	if name := filepath.Base(os.Args[0]); name == "golangci-lint" || name == "golangci-lint.exe" {
+		if len(os.Args) > 2 && os.Args[1] == "completion" {
+			fmt.Printf("# golangci-lint completion for %s\n", os.Args[2])
+
+			return
+		}
+
+		fmt.Printf("golangci-lint has version %s\n", strings.TrimPrefix(filepath.Base(versionDir(os.Args[0])), "v"))
+
+		return
+	}
+
+	os.Exit(m.Run())
+}
+
+// versionDir returns the closest parent directory of path named after a version, such as v1.55.2.
+func versionDir(path string) string {
+	dir := filepath.Dir(path)
+
+	for {
+		base := filepath.Base(dir)
+		if len(base) > 1 && base[0] == 'v' && base[1] >= '0' && base[1] <= '9' {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+
+		dir = parent
+	}
+}
+
+// fakeLinter returns a binary standing for golangci-lint: the test binary itself (see TestMain).
+func fakeLinter(t *testing.T) []byte {
+	t.Helper()
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatalf("Failed to locate test binary: %v", err)
+	}
+
+	content, err := os.ReadFile(executable) //nolint:gosec // Path of the running test binary
+	if err != nil {
+		t.Fatalf("Failed to read test binary: %v", err)
+	}
+
+	return content
+}
+
 // setupTestEnv creates a temporary test environment.
 func setupTestEnv(t *testing.T) (string, func()) {
 	t.Helper()
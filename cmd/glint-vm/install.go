@@ -4,55 +4,165 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/urfave/cli/v3"
 	"github.com/youkoulayley/glint-vm/internal/config"
+	"github.com/youkoulayley/glint-vm/internal/detector"
 	"github.com/youkoulayley/glint-vm/internal/downloader"
-	"github.com/youkoulayley/glint-vm/internal/shell"
 )
 
-// installCommand pre-downloads a specific version.
+// installCommand pre-downloads one or more versions.
 func installCommand(ctx context.Context, cmd *cli.Command) error {
+	cfg, err := targetConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("use") && !cfg.IsHostPlatform() {
+		return fmt.Errorf("--use with --platform %s: %w", cmd.String("platform"), config.ErrForeignPlatform)
+	}
+
+	if cmd.String("from-file") != "" {
+		return installFromFile(ctx, cmd, cfg)
+	}
+
 	if cmd.NArg() < 1 {
 		return ErrVersionRequired
 	}
 
-	version := config.NormalizeVersion(cmd.Args().First())
+	if cmd.NArg() > 1 {
+		if cmd.Bool("use") {
+			return ErrUseSingleVersion
+		}
+
+		return installVersions(ctx, cmd, cmd.Args().Slice())
+	}
+
+	dl, err := newDownloader(cmd)
+	if err != nil {
+		return err
+	}
 
-	dl, err := downloader.NewDownloader()
+	version, err := dl.ResolveVersion(ctx, cmd.Args().First())
 	if err != nil {
-		return fmt.Errorf("failed to initialize downloader: %w", err)
+		return err
 	}
 
 	if err := dl.Download(ctx, version); err != nil {
 		return fmt.Errorf("download failed: %w", err)
 	}
 
+	return reportInstalled(ctx, cmd, cfg, version)
+}
+
+// installFromFile installs a version from a local archive or binary.
+// The version is taken from the argument, or inferred from the file name.
+func installFromFile(ctx context.Context, cmd *cli.Command, cfg *config.Config) error {
+	filePath := cmd.String("from-file")
+
+	version := config.NormalizeVersion(cmd.Args().First())
+	if version == "" {
+		version = detector.GetFilenamePattern().ExtractVersion(filepath.Base(filePath))
+	}
+
+	if version == "" {
+		return fmt.Errorf("%w: cannot infer it from %s", ErrVersionRequired, filePath)
+	}
+
+	dl, err := newDownloader(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := dl.InstallFromFile(ctx, filePath, version, cmd.String("checksum-file")); err != nil {
+		return fmt.Errorf("install failed: %w", err)
+	}
+
+	return reportInstalled(ctx, cmd, cfg, version)
+}
+
+// reportInstalled activates the installed version when --use is set,
+// or tells the user how to activate or, for other platforms, export it.
+func reportInstalled(ctx context.Context, cmd *cli.Command, cfg *config.Config, version string) error {
+	if !cfg.IsHostPlatform() {
+		fmt.Fprintf(os.Stderr, "✓ Installed golangci-lint %s for %s/%s\n", version, cfg.OS, cfg.Arch)
+		fmt.Fprintf(os.Stderr, "  Location: %s\n", cfg.GetBinaryPath(version))
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "To copy it out, run:")
+		fmt.Fprintf(os.Stderr, "  glint-vm export --platform %s/%s --dest ./bin %s\n", cfg.OS, cfg.Arch, version)
+
+		return nil
+	}
+
 	if cmd.Bool("use") {
-		cfg, err := config.New()
+		return activateVersion(ctx, cfg, version)
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Installed golangci-lint %s\n", version)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "To activate this version, run:")
+	fmt.Fprintf(os.Stderr, "  glint-vm use %s\n", version)
+
+	return nil
+}
+
+// installVersions downloads several versions concurrently and reports the result of each.
+func installVersions(ctx context.Context, cmd *cli.Command, versions []string) error {
+	dl, err := newDownloader(cmd)
+	if err != nil {
+		return err
+	}
+
+	resolved := make([]string, 0, len(versions))
+
+	for _, spec := range versions {
+		version, err := dl.ResolveVersion(ctx, spec)
 		if err != nil {
-			return fmt.Errorf("failed to initialize config: %w", err)
+			return err
 		}
 
-		if err := cfg.SetCurrentVersion(version); err != nil {
-			return fmt.Errorf("failed to set current version: %w", err)
-		}
+		resolved = append(resolved, version)
+	}
 
-		shellName := shell.DetectShell()
+	results := dl.DownloadAll(ctx, resolved, cmd.Int("jobs"))
 
-		integrator, err := shell.NewIntegrator(shellName)
-		if err != nil {
-			return fmt.Errorf("failed to create shell integrator: %w", err)
+	failed := 0
+
+	fmt.Fprintln(os.Stderr)
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", result.Version, result.Err)
+
+			continue
 		}
 
-		output := integrator.GenerateUse(version)
-		fmt.Print(output)
-	} else {
-		fmt.Fprintf(os.Stderr, "✓ Installed golangci-lint %s\n", version)
-		fmt.Fprintln(os.Stderr)
-		fmt.Fprintln(os.Stderr, "To activate this version, run:")
-		fmt.Fprintf(os.Stderr, "  glint-vm use %s\n", version)
+		fmt.Fprintf(os.Stderr, "✓ Installed golangci-lint %s\n", result.Version)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d versions", ErrInstallFailed, failed, len(results))
 	}
 
 	return nil
 }
+
+// completeVersions suggests the versions of the cached release index, without network access.
+func completeVersions(_ context.Context, cmd *cli.Command) {
+	opts, err := downloaderOptions(cmd)
+	if err != nil {
+		return
+	}
+
+	dl, err := downloader.NewDownloader(opts)
+	if err != nil {
+		return
+	}
+
+	for _, version := range dl.CachedVersions() {
+		_, _ = fmt.Fprintln(cmd.Root().Writer, version)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+func TestInstallCommand_FromFile(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	t.Chdir(tmpDir)
+
+	content := fakeLinter(t)
+
+	var buf bytes.Buffer
+
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	_ = tw.WriteHeader(&tar.Header{
+		Name:     "golangci-lint-1.55.2-" + runtime.GOOS + "-" + runtime.GOARCH + "/golangci-lint",
+		Mode:     0o755,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gzw.Close()
+
+	archivePath := filepath.Join(tmpDir, "golangci-lint-1.55.2-"+runtime.GOOS+"-"+runtime.GOARCH+".tar.gz")
+
+	err := os.WriteFile(archivePath, buf.Bytes(), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+
+	app := &cli.Command{
+		Commands: []*cli.Command{
+			{
+				Name: "install",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "from-file"},
+					&cli.StringFlag{Name: "checksum-file"},
+					&cli.BoolFlag{Name: "use"},
+				},
+				Action: installCommand,
+			},
+		},
+	}
+
+	err = app.Run(context.Background(), []string{"glint-vm", "install", "--from-file", archivePath})
+	if err != nil {
+		t.Fatalf("install --from-file failed: %v", err)
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	if !cfg.BinaryExists("v1.55.2") {
+		t.Error("v1.55.2 should be installed with the version inferred from the file name")
+	}
+}
@@ -3,41 +3,62 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v3"
 	"github.com/youkoulayley/glint-vm/internal/config"
 	"github.com/youkoulayley/glint-vm/internal/downloader"
 )
 
-// listRemoteCommand lists available versions from GitHub.
+const sinceLayout = "2006-01-02"
+
+// listRemoteCommand lists available versions from the release source.
 func listRemoteCommand(ctx context.Context, cmd *cli.Command) error {
-	limit := cmd.Int("limit")
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	filter, err := releaseFilterFromFlags(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	opts, err := downloaderOptions(cmd)
+	if err != nil {
+		return err
+	}
 
-	fmt.Println("Fetching available golangci-lint versions from GitHub...")
+	dl, err := downloader.NewDownloader(opts)
+	if err != nil {
+		return fmt.Errorf("failed to initialize downloader: %w", err)
+	}
+
+	fmt.Println("Fetching available golangci-lint versions...")
 	fmt.Println()
 
-	releases, err := downloader.FetchAvailableVersions(ctx, limit)
+	releases, err := dl.FetchAvailableVersions(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to fetch versions: %w", err)
 	}
 
 	if len(releases) == 0 {
-		fmt.Println("No stable releases found.")
+		fmt.Println("No matching releases found.")
 
 		return nil
 	}
 
-	cfg, err := config.New()
-	if err != nil {
-		return fmt.Errorf("failed to read config: %w", err)
-	}
-
 	currentVersion, err := cfg.GetCurrentVersion()
 	if err != nil {
 		return fmt.Errorf("failed to get current version: %w", err)
 	}
 
-	fmt.Printf("Available versions (%d latest stable releases):\n", len(releases))
+	kind := "stable releases"
+	if filter.Prerelease {
+		kind = "releases"
+	}
+
+	fmt.Printf("Available versions (%d latest %s):\n", len(releases), kind)
 
 	for _, release := range releases {
 		marker := " "
@@ -50,8 +71,52 @@ func listRemoteCommand(ctx context.Context, cmd *cli.Command) error {
 			status = " (installed)"
 		}
 
-		fmt.Printf("%s %s%s\n", marker, release.TagName, status)
+		if release.Prerelease {
+			status += " (pre-release)"
+		}
+
+		fmt.Printf("%s %-16s %s%s\n", marker, release.TagName, formatPublished(release.PublishedAt), status)
 	}
 
 	return nil
 }
+
+// releaseFilterFromFlags builds the release filter from the list-remote flags.
+func releaseFilterFromFlags(cmd *cli.Command, cfg *config.Config) (downloader.ReleaseFilter, error) {
+	major := cmd.Int("major")
+	if major < 0 {
+		return downloader.ReleaseFilter{}, fmt.Errorf("%w: %d", ErrInvalidMajor, major)
+	}
+
+	filter := downloader.ReleaseFilter{
+		Limit:      cmd.Int("limit"),
+		Prerelease: cmd.Bool("pre"),
+		Major:      uint64(major),
+	}
+
+	if since := cmd.String("since"); since != "" {
+		date, err := time.ParseInLocation(sinceLayout, since, time.Local)
+		if err != nil {
+			return downloader.ReleaseFilter{}, fmt.Errorf("%w: %q, expected YYYY-MM-DD", ErrInvalidSince, since)
+		}
+
+		filter.Since = date
+	}
+
+	if cmd.Bool("installed-only") {
+		filter.Match = func(release downloader.Release) bool {
+			return cfg.BinaryExists(release.TagName)
+		}
+	}
+
+	return filter, nil
+}
+
+// formatPublished formats a release date, or a placeholder when the API didn't provide one.
+func formatPublished(published time.Time) string {
+	if published.IsZero() {
+		return "          "
+	}
+
+	return published.Local().Format(sinceLayout)
+}
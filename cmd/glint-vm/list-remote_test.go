@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/downloader"
+)
+
+// newListRemoteApp returns an app running list-remote against the given releases API.
+func newListRemoteApp() *cli.Command {
+	return &cli.Command{
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: "mirror"},
+		},
+		Commands: []*cli.Command{
+			{
+				Name: "list-remote",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "limit", Value: limitRelease},
+					&cli.BoolFlag{Name: "pre"},
+					&cli.IntFlag{Name: "major"},
+					&cli.StringFlag{Name: "since"},
+					&cli.BoolFlag{Name: "installed-only"},
+				},
+				Action: listRemoteCommand,
+			},
+		},
+	}
+}
+
+func TestListRemoteCommand_Filters(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	_, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	releases := []downloader.Release{
+		{TagName: "v2.1.0", PublishedAt: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{TagName: "v1.64.8", PublishedAt: time.Date(2025, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{TagName: "v1.64.7", PublishedAt: time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC)},
+	}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(releases)
+	}))
+	defer api.Close()
+
+	var err error
+
+	output := captureOutput(func() {
+		err = newListRemoteApp().Run(context.Background(), []string{
+			"glint-vm", "--mirror", api.URL + ";api=" + api.URL, "list-remote", "--major", "1", "--limit", "1",
+		})
+	})
+	if err != nil {
+		t.Fatalf("list-remote failed: %v", err)
+	}
+
+	if !strings.Contains(output, "v1.64.8") || !strings.Contains(output, "2025-03-17") {
+		t.Errorf("Output should list v1.64.8 with its publication date, got: %s", output)
+	}
+
+	if strings.Contains(output, "v2.1.0") || strings.Contains(output, "v1.64.7") {
+		t.Errorf("Output should only list the latest v1 release, got: %s", output)
+	}
+}
+
+func TestListRemoteCommand_InvalidSince(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	_, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	err := newListRemoteApp().Run(context.Background(), []string{"glint-vm", "list-remote", "--since", "last week"})
+	if !errors.Is(err, ErrInvalidSince) {
+		t.Errorf("list-remote error = %v, want %v", err, ErrInvalidSince)
+	}
+}
@@ -3,25 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 	"github.com/youkoulayley/glint-vm/internal/config"
 	"github.com/youkoulayley/glint-vm/internal/downloader"
 )
 
-// listCommand lists all installed versions.
-func listCommand(_ context.Context, _ *cli.Command) error {
-	cfg, err := config.New()
+// listCommand lists all installed versions, of the host or of the platform selected with --platform.
+func listCommand(_ context.Context, cmd *cli.Command) error {
+	cfg, err := targetConfig(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to initialize config: %w", err)
+		return err
 	}
 
-	cm, err := downloader.NewCacheManager()
-	if err != nil {
-		return fmt.Errorf("failed to initialize cache manager: %w", err)
-	}
-
-	versions, err := cm.List()
+	versions, err := downloader.NewCacheManagerFor(cfg).List()
 	if err != nil {
 		return fmt.Errorf("failed to list versions: %w", err)
 	}
@@ -32,12 +28,21 @@ func listCommand(_ context.Context, _ *cli.Command) error {
 		fmt.Println("Install a version with:")
 		fmt.Println("  glint-vm install v1.55.2")
 
+		printOtherPlatforms(cfg)
+
 		return nil
 	}
 
-	currentVersion, _ := cfg.GetCurrentVersion()
+	// Only host versions can be activated
+	currentVersion := ""
 
-	fmt.Println("Installed versions:")
+	if cfg.IsHostPlatform() {
+		currentVersion, _ = cfg.GetCurrentVersion()
+
+		fmt.Println("Installed versions:")
+	} else {
+		fmt.Printf("Installed versions for %s:\n", cfg.GetPlatformString())
+	}
 
 	for _, version := range versions {
 		marker := " "
@@ -50,6 +55,10 @@ func listCommand(_ context.Context, _ *cli.Command) error {
 			status = " (incomplete)"
 		}
 
+		if note := installNote(version.Info); note != "" {
+			status += " (" + note + ")"
+		}
+
 		fmt.Printf("%s %s%s\n", marker, version.Version, status)
 	}
 
@@ -58,5 +67,38 @@ func listCommand(_ context.Context, _ *cli.Command) error {
 		fmt.Printf("* = current version (%s)\n", currentVersion)
 	}
 
+	printOtherPlatforms(cfg)
+
 	return nil
 }
+
+// printOtherPlatforms lists the platforms with versions cached apart from the host ones,
+// which commands reach through --platform.
+func printOtherPlatforms(cfg *config.Config) {
+	if !cfg.IsHostPlatform() {
+		return
+	}
+
+	platforms, err := cfg.ListPlatforms()
+	if err != nil || len(platforms) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Other platforms (select with --platform): %s\n", strings.Join(platforms, ", "))
+}
+
+// installNote describes how an emulated or source-built version was installed,
+// or returns an empty string for versions installed from their native release archive.
+func installNote(info config.VersionInfo) string {
+	switch {
+	case info.Emulated:
+		return "emulated: " + info.Platform + " under Rosetta 2"
+	case info.SourceBuilt && info.GoVersion != "":
+		return "built from source with " + info.GoVersion
+	case info.SourceBuilt:
+		return "built from source"
+	default:
+		return ""
+	}
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/config"
 )
 
 func TestListCommand_NoVersions(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
@@ -29,3 +31,122 @@ func TestListCommand_NoVersions(t *testing.T) { //nolint:paralleltest // uses t.
 		t.Errorf("Output should indicate no versions, got: %s", output)
 	}
 }
+
+func TestListCommand_Emulated(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	_, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	if err := cfg.EnsureVersionDir("v1.30.0"); err != nil {
+		t.Fatalf("Failed to create version directory: %v", err)
+	}
+
+	//nolint:gosec // Test binary must be executable
+	if err := os.WriteFile(cfg.GetBinaryPath("v1.30.0"), []byte("binary"), 0o755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+
+	info := config.VersionInfo{Platform: "darwin-amd64", Emulated: true}
+	if err := config.WriteVersionInfo(cfg.GetVersionDir("v1.30.0"), info); err != nil {
+		t.Fatalf("WriteVersionInfo() failed: %v", err)
+	}
+
+	app := &cli.Command{
+		Commands: []*cli.Command{
+			{
+				Name:   "list",
+				Action: listCommand,
+			},
+		},
+	}
+
+	output := captureOutput(func() {
+		_ = app.Run(context.Background(), []string{"glint-vm", "list"})
+	})
+
+	if !strings.Contains(output, "v1.30.0 (emulated: darwin-amd64 under Rosetta 2)") {
+		t.Errorf("Output should mark v1.30.0 as emulated, got: %s", output)
+	}
+}
+
+func TestListCommand_Platform(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	_, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	foreign := cfg.ForPlatform("plan9", "riscv64")
+
+	if err := foreign.EnsureVersionDir("v1.55.2"); err != nil {
+		t.Fatalf("Failed to create version directory: %v", err)
+	}
+
+	//nolint:gosec // Test binary must be executable
+	if err := os.WriteFile(foreign.GetBinaryPath("v1.55.2"), []byte("binary"), 0o755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+
+	platformFlag := &cli.StringFlag{Name: "platform"}
+	app := &cli.Command{
+		Commands: []*cli.Command{
+			{Name: "list", Flags: []cli.Flag{platformFlag}, Action: listCommand},
+			{Name: "uninstall", Flags: []cli.Flag{platformFlag}, Action: uninstallCommand},
+		},
+	}
+
+	output := captureOutput(func() {
+		_ = app.Run(context.Background(), []string{"glint-vm", "list"})
+	})
+
+	if !strings.Contains(output, "No versions installed") || !strings.Contains(output, "plan9-riscv64") {
+		t.Errorf("Host list should point to the plan9-riscv64 cache, got: %s", output)
+	}
+
+	output = captureOutput(func() {
+		_ = app.Run(context.Background(), []string{"glint-vm", "list", "--platform", "plan9/riscv64"})
+	})
+
+	if !strings.Contains(output, "Installed versions for plan9-riscv64") || !strings.Contains(output, "v1.55.2") {
+		t.Errorf("Output should list v1.55.2 for plan9-riscv64, got: %s", output)
+	}
+
+	if err := app.Run(context.Background(), []string{"glint-vm", "uninstall", "--platform", "plan9/riscv64", "v1.55.2"}); err != nil {
+		t.Fatalf("uninstall failed: %v", err)
+	}
+
+	if foreign.BinaryExists("v1.55.2") {
+		t.Error("v1.55.2 should be removed from the plan9-riscv64 cache")
+	}
+}
+
+func TestInstallNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		info config.VersionInfo
+		want string
+	}{
+		{name: "native", info: config.VersionInfo{}, want: ""},
+		{name: "emulated", info: config.VersionInfo{Platform: "darwin-amd64", Emulated: true}, want: "emulated: darwin-amd64 under Rosetta 2"},
+		{name: "source-built", info: config.VersionInfo{SourceBuilt: true, GoVersion: "go1.23.4"}, want: "built from source with go1.23.4"},
+		{name: "source-built without Go version", info: config.VersionInfo{SourceBuilt: true}, want: "built from source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := installNote(tt.info); got != tt.want {
+				t.Errorf("installNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/detector"
+	"github.com/youkoulayley/glint-vm/internal/downloader"
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+// lockCommand writes a lockfile pinning a version and its per-platform archive checksums.
+func lockCommand(ctx context.Context, cmd *cli.Command) error {
+	opts, err := downloaderOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	dl, err := downloader.NewDownloader(opts)
+	if err != nil {
+		return fmt.Errorf("failed to initialize downloader: %w", err)
+	}
+
+	version, err := dl.ResolveVersion(ctx, cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	if version == "" {
+		result, err := detector.QuickDetect()
+		if err != nil {
+			return fmt.Errorf("detection failed: %w", err)
+		}
+
+		if result == nil {
+			return ErrVersionRequired
+		}
+
+		version = result.Version
+	}
+
+	fmt.Fprintf(os.Stderr, "Fetching checksums for golangci-lint %s...\n", version)
+
+	checksums, err := dl.FetchChecksums(ctx, version, lockfile.DefaultPlatforms())
+	if err != nil {
+		return fmt.Errorf("failed to fetch checksums: %w", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	lock := &lockfile.Lockfile{
+		Version:   version,
+		Checksums: checksums,
+	}
+
+	if err := lock.Save(cwd); err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	fmt.Printf("✓ Locked golangci-lint %s in %s\n", version, lockfile.FileName)
+
+	for _, platform := range lock.Platforms() {
+		fmt.Printf("  %s  %s\n", platform, checksums[platform])
+	}
+
+	return nil
+}
@@ -5,17 +5,23 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/downloader"
 	"github.com/youkoulayley/glint-vm/internal/version"
 )
 
 const (
 	limitRelease = 20
 	keepVersions = 3
+	installJobs  = 4
 )
 
 func main() {
+	cancelTimeout := context.CancelFunc(func() {})
+
 	app := &cli.Command{
 		Name:                   "glint-vm",
 		Usage:                  "golangci-lint version manager - like gvm/nvm for golangci-lint",
@@ -43,6 +49,91 @@ func main() {
    5. CircleCI (.circleci/config.yml)
    6. GitLab CI (.gitlab-ci.yml)`,
 		Version: fmt.Sprintf("%s (commit: %s, built: %s)", version.Get(), version.GetCommit(), version.GetDate()),
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "mirror",
+				Usage:   "Release mirror to download from, tried in order (base URL with optional ;archive=,;checksum=,;manifest=,;api= options)",
+				Sources: cli.EnvVars(downloader.MirrorsEnvVar),
+			},
+			&cli.StringFlag{
+				Name:    "source",
+				Usage:   "Release source: github (github.com or GitHub Enterprise), gitea, index (static index.json), oci (image registry) or go (build from the Go module proxy)",
+				Value:   downloader.SourceGitHub,
+				Sources: cli.EnvVars(downloader.SourceEnvVar),
+			},
+			&cli.StringFlag{
+				Name:    "source-url",
+				Usage:   "GitHub Enterprise, Gitea or OCI registry base URL, or the URL of the static index.json",
+				Sources: cli.EnvVars(downloader.SourceURLEnvVar),
+			},
+			&cli.StringFlag{
+				Name:    "repo",
+				Usage:   "Repository (owner/name) publishing golangci-lint releases, images or modules",
+				Value:   downloader.DefaultRepo,
+				Sources: cli.EnvVars(downloader.RepoEnvVar),
+			},
+			&cli.BoolFlag{
+				Name:    "require-checksum",
+				Usage:   "Refuse to install binaries whose checksum is missing or malformed",
+				Sources: cli.EnvVars(downloader.StrictEnvVar),
+			},
+			&cli.BoolFlag{
+				Name:    "frozen",
+				Usage:   "Fail when .golangci-lint.lock is missing or doesn't match the requested version",
+				Sources: cli.EnvVars(downloader.FrozenEnvVar),
+			},
+			&cli.IntFlag{
+				Name:    "retries",
+				Usage:   "Number of attempts for each download before giving up on transient network errors",
+				Value:   downloader.DefaultRetryAttempts,
+				Sources: cli.EnvVars(downloader.RetriesEnvVar),
+			},
+			&cli.DurationFlag{
+				Name:    "timeout",
+				Usage:   "Overall time limit for the command, including retries (e.g. 2m, 0 for none)",
+				Sources: cli.EnvVars(downloader.TimeoutEnvVar),
+			},
+			&cli.BoolFlag{
+				Name:    "rosetta",
+				Usage:   "On Apple Silicon, install darwin-amd64 binaries running under Rosetta 2 for releases without darwin-arm64 archive",
+				Sources: cli.EnvVars(downloader.RosettaEnvVar),
+			},
+			&cli.BoolFlag{
+				Name:    "build-from-source",
+				Usage:   "Build golangci-lint with the Go toolchain for releases without archive for the platform (honours GOPROXY and GOFLAGS)",
+				Sources: cli.EnvVars(downloader.BuildFromSourceEnvVar),
+			},
+			&cli.BoolFlag{
+				Name:    "keep-docs",
+				Usage:   "Keep the README, LICENSE and completion files shipped in release archives, under versions/<version>/docs",
+				Sources: cli.EnvVars(downloader.KeepDocsEnvVar),
+			},
+			&cli.StringFlag{
+				Name:    "ca-file",
+				Usage:   "PEM CA bundle to trust in addition to the system roots (e.g. for a TLS-intercepting proxy)",
+				Sources: cli.EnvVars(downloader.CAFileEnvVar),
+			},
+			&cli.StringFlag{
+				Name:    "client-cert",
+				Usage:   "PEM client certificate presented to mirrors requiring mutual TLS",
+				Sources: cli.EnvVars(downloader.ClientCertEnvVar),
+			},
+			&cli.StringFlag{
+				Name:    "client-key",
+				Usage:   "PEM private key of --client-cert",
+				Sources: cli.EnvVars(downloader.ClientKeyEnvVar),
+			},
+		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			timeout := cmd.Duration("timeout")
+			if timeout <= 0 {
+				return ctx, nil
+			}
+
+			ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+
+			return ctx, nil
+		},
 		Commands: []*cli.Command{
 			{
 				Name:      "init",
@@ -80,22 +171,66 @@ func main() {
 			},
 			{
 				Name:      "install",
-				Usage:     "Download a specific golangci-lint version",
-				ArgsUsage: "<version>",
+				Usage:     "Download one or more golangci-lint versions",
+				ArgsUsage: "<version|latest|constraint> [version...]",
 				Flags: []cli.Flag{
 					&cli.BoolFlag{
 						Name:    "use",
 						Aliases: []string{"u"},
 						Usage:   "Activate the version after installing",
 					},
+					&cli.IntFlag{
+						Name:    "jobs",
+						Aliases: []string{"j"},
+						Usage:   "Maximum number of versions downloaded in parallel",
+						Value:   installJobs,
+					},
+					&cli.StringFlag{
+						Name:  "from-file",
+						Usage: "Install from a local release archive or binary instead of downloading",
+					},
+					&cli.StringFlag{
+						Name:  "checksum-file",
+						Usage: "Checksum file (.sha256 or checksums.txt) to verify --from-file against",
+					},
+					&cli.StringFlag{
+						Name:  "platform",
+						Usage: "Install for another platform (os/arch, e.g. linux/arm64 or linux/arm/v7); such binaries can be exported, not activated",
+					},
 				},
-				Action: installCommand,
+				Action:        installCommand,
+				ShellComplete: completeVersions,
 			},
 			{
-				Name:      "use",
-				Usage:     "Activate a specific version in current shell",
-				ArgsUsage: "<version>",
-				Action:    useCommand,
+				Name:          "use",
+				Usage:         "Activate a specific version in current shell",
+				ArgsUsage:     "<version>",
+				Action:        useCommand,
+				ShellComplete: completeVersions,
+			},
+			{
+				Name:      "export",
+				Usage:     "Copy a verified binary out of the cache, installing it if needed",
+				ArgsUsage: "[version]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "platform",
+						Usage: "Platform of the binary (os/arch, e.g. linux/arm64 or linux/arm/v7), the host by default",
+					},
+					&cli.StringFlag{
+						Name:     "dest",
+						Usage:    "Directory the binary is copied to",
+						Required: true,
+					},
+				},
+				Action:        exportCommand,
+				ShellComplete: completeVersions,
+			},
+			{
+				Name:      "lock",
+				Usage:     "Pin a version and its per-platform checksums in .golangci-lint.lock",
+				ArgsUsage: "[version]",
+				Action:    lockCommand,
 			},
 			{
 				Name:   "current",
@@ -103,13 +238,19 @@ func main() {
 				Action: currentCommand,
 			},
 			{
-				Name:   "list",
-				Usage:  "List installed versions",
+				Name:  "list",
+				Usage: "List installed versions",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "platform",
+						Usage: "List versions installed for another platform (os/arch, e.g. linux/arm64 or linux/arm/v7)",
+					},
+				},
 				Action: listCommand,
 			},
 			{
 				Name:    "list-remote",
-				Usage:   "List available versions from GitHub",
+				Usage:   "List available versions from the release source",
 				Aliases: []string{"lr"},
 				Flags: []cli.Flag{
 					&cli.IntFlag{
@@ -118,22 +259,64 @@ func main() {
 						Usage:   "Limit the number of versions to display",
 						Value:   limitRelease,
 					},
+					&cli.BoolFlag{
+						Name:  "pre",
+						Usage: "Include pre-releases",
+					},
+					&cli.IntFlag{
+						Name:  "major",
+						Usage: "Only list versions of this major version (e.g. 1 or 2)",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only list versions published on or after this date (YYYY-MM-DD)",
+					},
+					&cli.BoolFlag{
+						Name:  "installed-only",
+						Usage: "Only list versions installed locally",
+					},
 				},
 				Action: listRemoteCommand,
 			},
+			{
+				Name:      "changelog",
+				Usage:     "Show the release notes between two versions (detected version and latest by default)",
+				ArgsUsage: "[from] [to]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Output format: markdown or json",
+						Value: formatMarkdown,
+					},
+				},
+				Action:        changelogCommand,
+				ShellComplete: completeVersions,
+			},
 			{
 				Name:      "uninstall",
 				Usage:     "Remove a specific version",
 				ArgsUsage: "<version>",
-				Action:    uninstallCommand,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "platform",
+						Usage: "Remove versions installed for another platform (os/arch, e.g. linux/arm64 or linux/arm/v7)",
+					},
+				},
+				Action: uninstallCommand,
 			},
 			{
 				Name:  "cache",
 				Usage: "Manage cached golangci-lint versions",
 				Commands: []*cli.Command{
 					{
-						Name:   "list",
-						Usage:  "List all cached versions",
+						Name:  "list",
+						Usage: "List all cached versions",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "platform",
+								Usage: "List versions installed for another platform (os/arch, e.g. linux/arm64 or linux/arm/v7)",
+							},
+						},
 						Action: cacheListCommand,
 					},
 					{
@@ -151,6 +334,10 @@ func main() {
 								Usage:   "Keep the N most recent versions",
 								Value:   keepVersions,
 							},
+							&cli.StringFlag{
+								Name:  "platform",
+								Usage: "Clean versions installed for another platform (os/arch, e.g. linux/arm64 or linux/arm/v7)",
+							},
 						},
 						Action: cacheCleanCommand,
 					},
@@ -160,7 +347,14 @@ func main() {
 		EnableShellCompletion: true,
 	}
 
-	err := app.Run(context.Background(), os.Args)
+	// Cancel on Ctrl-C so in-progress installs clean up after themselves
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := app.Run(ctx, os.Args)
+
+	cancelTimeout()
+	stop()
+
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 
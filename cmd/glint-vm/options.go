@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"github.com/youkoulayley/glint-vm/internal/config"
+	"github.com/youkoulayley/glint-vm/internal/downloader"
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+// mirrorsFromFlags parses the mirrors configured through --mirror or GLINT_VM_MIRRORS.
+func mirrorsFromFlags(cmd *cli.Command) ([]downloader.Mirror, error) {
+	mirrors, err := downloader.ParseMirrors(cmd.StringSlice("mirror"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mirrors: %w", err)
+	}
+
+	return mirrors, nil
+}
+
+// sourceFromFlags creates the release source configured through --source, --source-url and --repo.
+func sourceFromFlags(cmd *cli.Command) (downloader.ReleaseSource, error) {
+	kind := cmd.String("source")
+
+	source, err := downloader.NewReleaseSource(downloader.SourceConfig{
+		Kind:     kind,
+		URL:      cmd.String("source-url"),
+		Repo:     cmd.String("repo"),
+		Token:    sourceToken(kind),
+		Username: os.Getenv(downloader.RegistryUsernameEnvVar),
+		Password: os.Getenv(downloader.RegistryPasswordEnvVar),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure release source: %w", err)
+	}
+
+	return source, nil
+}
+
+// sourceToken returns the token authenticating GitHub Enterprise or Gitea API calls.
+func sourceToken(kind string) string {
+	if kind == downloader.SourceGitea {
+		return os.Getenv("GITEA_TOKEN")
+	}
+
+	if token := os.Getenv("GH_ENTERPRISE_TOKEN"); token != "" {
+		return token
+	}
+
+	return os.Getenv("GITHUB_ENTERPRISE_TOKEN")
+}
+
+// downloaderOptions builds downloader options from the global flags, without lockfile verification.
+func downloaderOptions(cmd *cli.Command) (downloader.Options, error) {
+	mirrors, err := mirrorsFromFlags(cmd)
+	if err != nil {
+		return downloader.Options{}, err
+	}
+
+	source, err := sourceFromFlags(cmd)
+	if err != nil {
+		return downloader.Options{}, err
+	}
+
+	return downloader.Options{
+		Mirrors:         mirrors,
+		Source:          source,
+		RequireChecksum: cmd.Bool("require-checksum"),
+		Retry:           downloader.RetryPolicy{Attempts: cmd.Int("retries")},
+		TLS: downloader.TLSOptions{
+			CAFile:     cmd.String("ca-file"),
+			ClientCert: cmd.String("client-cert"),
+			ClientKey:  cmd.String("client-key"),
+		},
+		GitHubToken:     githubToken(),
+		Platform:        cmd.String("platform"),
+		RosettaFallback: cmd.Bool("rosetta"),
+		KeepDocs:        cmd.Bool("keep-docs"),
+		BuildFromSource: cmd.Bool("build-from-source"),
+	}, nil
+}
+
+// targetConfig returns the config of the platform selected with --platform, the host by default.
+func targetConfig(cmd *cli.Command) (*config.Config, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize config: %w", err)
+	}
+
+	platform := cmd.String("platform")
+	if platform == "" {
+		return cfg, nil
+	}
+
+	goos, goarch, err := config.ParsePlatform(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.ForPlatform(goos, goarch), nil
+}
+
+// githubToken returns the GitHub token from GITHUB_TOKEN, or GH_TOKEN as used by the gh CLI.
+func githubToken() string {
+	if token := os.Getenv(downloader.GitHubTokenEnvVar); token != "" {
+		return token
+	}
+
+	return os.Getenv(downloader.GHTokenEnvVar)
+}
+
+// newDownloader creates a downloader configured from the global flags,
+// verifying downloads against the project lockfile when there is one.
+func newDownloader(cmd *cli.Command) (*downloader.Downloader, error) {
+	opts, err := downloaderOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := loadProjectLock()
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Lock = lock
+	opts.Frozen = cmd.Bool("frozen")
+
+	dl, err := downloader.NewDownloader(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize downloader: %w", err)
+	}
+
+	return dl, nil
+}
+
+// loadProjectLock loads the lockfile from the current directory, if any.
+func loadProjectLock() (*lockfile.Lockfile, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		if errors.Is(err, lockfile.ErrNotFound) {
+			return nil, nil //nolint:nilnil // No lockfile, not an error
+		}
+
+		return nil, fmt.Errorf("failed to load lockfile: %w", err)
+	}
+
+	return lock, nil
+}
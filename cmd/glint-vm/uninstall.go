@@ -10,7 +10,7 @@ import (
 	"github.com/youkoulayley/glint-vm/internal/downloader"
 )
 
-// uninstallCommand removes a specific version.
+// uninstallCommand removes a specific version, of the host or of the platform selected with --platform.
 func uninstallCommand(_ context.Context, cmd *cli.Command) error {
 	if cmd.NArg() < 1 {
 		return ErrVersionRequired
@@ -18,26 +18,23 @@ func uninstallCommand(_ context.Context, cmd *cli.Command) error {
 
 	version := config.NormalizeVersion(cmd.Args().First())
 
-	cfg, err := config.New()
+	cfg, err := targetConfig(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to initialize config: %w", err)
+		return err
 	}
 
-	currentVersion, _ := cfg.GetCurrentVersion()
-	if version == currentVersion {
-		log.Warn().Msgf("Warning: %s is currently active. You may want to switch to another version first.", version)
+	if cfg.IsHostPlatform() {
+		currentVersion, _ := cfg.GetCurrentVersion()
+		if version == currentVersion {
+			log.Warn().Msgf("Warning: %s is currently active. You may want to switch to another version first.", version)
+		}
 	}
 
 	if !cfg.BinaryExists(version) {
 		return fmt.Errorf("version %s: %w", version, config.ErrVersionNotInstalled)
 	}
 
-	cm, err := downloader.NewCacheManager()
-	if err != nil {
-		return fmt.Errorf("failed to initialize cache manager: %w", err)
-	}
-
-	if err := cm.Remove(version); err != nil {
+	if err := downloader.NewCacheManagerFor(cfg).Remove(version); err != nil {
 		return fmt.Errorf("failed to remove version: %w", err)
 	}
 
@@ -2,11 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli/v3"
 	"github.com/youkoulayley/glint-vm/internal/config"
-	"github.com/youkoulayley/glint-vm/internal/downloader"
 	"github.com/youkoulayley/glint-vm/internal/shell"
 )
 
@@ -16,11 +17,14 @@ func useCommand(ctx context.Context, cmd *cli.Command) error {
 		return ErrVersionRequired
 	}
 
-	version := config.NormalizeVersion(cmd.Args().First())
+	dl, err := newDownloader(cmd)
+	if err != nil {
+		return err
+	}
 
-	dl, err := downloader.NewDownloader()
+	version, err := dl.ResolveVersion(ctx, cmd.Args().First())
 	if err != nil {
-		return fmt.Errorf("failed to initialize downloader: %w", err)
+		return err
 	}
 
 	if err := dl.Download(ctx, version); err != nil {
@@ -32,10 +36,18 @@ func useCommand(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to initialize config: %w", err)
 	}
 
+	return activateVersion(ctx, cfg, version)
+}
+
+// activateVersion makes an installed version the current one, along with its golangci-lint
+// completions, and prints the shell code activating it.
+func activateVersion(ctx context.Context, cfg *config.Config, version string) error {
 	if err := cfg.SetCurrentVersion(version); err != nil {
 		return fmt.Errorf("failed to set current version: %w", err)
 	}
 
+	installCompletions(ctx, cfg, version)
+
 	shellName := shell.DetectShell()
 
 	integrator, err := shell.NewIntegrator(shellName)
@@ -48,3 +60,37 @@ func useCommand(ctx context.Context, cmd *cli.Command) error {
 
 	return nil
 }
+
+// installCompletions generates the completion scripts of a version once, by running its
+// completion subcommand, and makes them current.
+func installCompletions(ctx context.Context, cfg *config.Config, version string) {
+	for _, shellName := range shell.SupportedShells() {
+		if err := generateCompletion(ctx, cfg, version, shellName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: no %s completion for golangci-lint %s: %v\n", shellName, version, err)
+		}
+
+		if err := cfg.SetCurrentCompletion(version, shellName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// generateCompletion stores the completion script of a version for a shell, unless it's stored
+// already. Versions without completion command are recorded as such, so that they are only run once;
+// other failures store nothing, so that the next use retries.
+func generateCompletion(ctx context.Context, cfg *config.Config, version, shellName string) error {
+	if _, err := os.Stat(cfg.GetCompletionPath(version, shellName)); err == nil || cfg.IsCompletionUnsupported(version, shellName) {
+		return nil
+	}
+
+	script, err := shell.GenerateCompletion(ctx, cfg.GetBinaryPath(version), shellName)
+	if errors.Is(err, shell.ErrCompletionUnsupported) {
+		return cfg.MarkCompletionUnsupported(version, shellName)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return cfg.WriteCompletion(version, shellName, script)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+func TestInstallCompletions(t *testing.T) { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping symlink test on Windows")
+	}
+
+	_, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	if err := cfg.EnsureVersionDir("v1.55.2"); err != nil {
+		t.Fatalf("Failed to create version directory: %v", err)
+	}
+
+	//nolint:gosec // Test binary must be executable
+	if err := os.WriteFile(cfg.GetBinaryPath("v1.55.2"), fakeLinter(t), 0o755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+
+	if err := cfg.SetCurrentVersion("v1.55.2"); err != nil {
+		t.Fatalf("SetCurrentVersion() failed: %v", err)
+	}
+
+	installCompletions(context.Background(), cfg, "v1.55.2")
+
+	for _, shellName := range []string{"bash", "zsh"} {
+		content, err := os.ReadFile(cfg.GetCurrentCompletionPath(shellName))
+		if err != nil {
+			t.Fatalf("Failed to read current %s completion: %v", shellName, err)
+		}
+
+		if want := "# golangci-lint completion for " + shellName + "\n"; string(content) != want {
+			t.Errorf("%s completion = %q, want %q", shellName, content, want)
+		}
+	}
+}
+
+func TestInstallCompletions_Failure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping symlink test on Windows")
+	}
+
+	tests := []struct {
+		name            string
+		binary          []byte // nil for a missing binary
+		wantUnsupported bool
+	}{
+		{name: "no completion command", binary: []byte("#!/bin/sh\nexit 1\n"), wantUnsupported: true},
+		{name: "binary failing to run"},
+	}
+
+	for _, test := range tests { //nolint:paralleltest // uses t.Setenv via setupTestEnv
+		t.Run(test.name, func(t *testing.T) {
+			_, cleanup := setupTestEnv(t)
+			defer cleanup()
+
+			cfg, err := config.New()
+			if err != nil {
+				t.Fatalf("Failed to create config: %v", err)
+			}
+
+			if err := cfg.EnsureVersionDir("v1.55.2"); err != nil {
+				t.Fatalf("Failed to create version directory: %v", err)
+			}
+
+			if test.binary != nil {
+				//nolint:gosec // Test binary must be executable
+				if err := os.WriteFile(cfg.GetBinaryPath("v1.55.2"), test.binary, 0o755); err != nil {
+					t.Fatalf("Failed to write binary: %v", err)
+				}
+			}
+
+			installCompletions(context.Background(), cfg, "v1.55.2")
+
+			if _, err := os.Stat(cfg.GetCompletionPath("v1.55.2", "bash")); !os.IsNotExist(err) {
+				t.Fatalf("completion should not be stored after a failure, got %v", err)
+			}
+
+			if got := cfg.IsCompletionUnsupported("v1.55.2", "bash"); got != test.wantUnsupported {
+				t.Fatalf("IsCompletionUnsupported() = %v, want %v", got, test.wantUnsupported)
+			}
+
+			// Versions without completion command aren't run again; other failures are retried
+			//nolint:gosec // Test binary must be executable
+			if err := os.WriteFile(cfg.GetBinaryPath("v1.55.2"), fakeLinter(t), 0o755); err != nil {
+				t.Fatalf("Failed to write binary: %v", err)
+			}
+
+			installCompletions(context.Background(), cfg, "v1.55.2")
+
+			_, err = os.Stat(cfg.GetCompletionPath("v1.55.2", "bash"))
+			if stored := err == nil; stored == test.wantUnsupported {
+				t.Errorf("completion stored on the next use = %v, want %v", stored, !test.wantUnsupported)
+			}
+		})
+	}
+}
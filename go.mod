@@ -4,12 +4,10 @@ go 1.25.5
 
 require (
 	github.com/Masterminds/semver/v3 v3.4.0
+	github.com/mattn/go-isatty v0.0.19
 	github.com/rs/zerolog v1.34.0
 	github.com/urfave/cli/v3 v3.6.2
+	golang.org/x/sys v0.12.0
 )
 
-require (
-	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.19 // indirect
-	golang.org/x/sys v0.12.0 // indirect
-)
+require github.com/mattn/go-colorable v0.1.13 // indirect
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// unsupportedCompletionSuffix names the marker recording, next to where its script would be,
+// that a version has no completion command for a shell.
+const unsupportedCompletionSuffix = ".unsupported"
+
+// CompletionFileName returns the file name of the golangci-lint completion script for a shell.
+func CompletionFileName(shellName string) string {
+	return "golangci-lint." + shellName
+}
+
+// GetCompletionPath returns the path of the golangci-lint completion script of a version for a shell.
+func (c *Config) GetCompletionPath(version, shellName string) string {
+	return filepath.Join(c.GetVersionDir(version), CompletionsDir, CompletionFileName(shellName))
+}
+
+// GetCurrentCompletionPath returns the path of the symlink to the completion script
+// of the current version, sourced by the shell integration.
+func (c *Config) GetCurrentCompletionPath(shellName string) string {
+	return filepath.Join(c.GetCurrentDir(), CompletionFileName(shellName))
+}
+
+// WriteCompletion stores the completion script of a version for a shell. The script is
+// written to a temporary file first, so that an interrupted write never leaves a partial one.
+func (c *Config) WriteCompletion(version, shellName string, script []byte) error {
+	completionPath := c.GetCompletionPath(version, shellName)
+
+	if err := os.MkdirAll(filepath.Dir(completionPath), directoryPermission); err != nil {
+		return fmt.Errorf("failed to create completions directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(completionPath), "."+filepath.Base(completionPath)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create completion: %w", err)
+	}
+
+	_, err = tmp.Write(script)
+
+	err = errors.Join(err, tmp.Chmod(filePermission), tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), completionPath)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write completion: %w", err)
+	}
+
+	return nil
+}
+
+// IsCompletionUnsupported reports whether a version was recorded as having no completion command for a shell.
+func (c *Config) IsCompletionUnsupported(version, shellName string) bool {
+	_, err := os.Stat(c.GetCompletionPath(version, shellName) + unsupportedCompletionSuffix)
+
+	return err == nil
+}
+
+// MarkCompletionUnsupported records that a version has no completion command for a shell,
+// so that it isn't run again.
+func (c *Config) MarkCompletionUnsupported(version, shellName string) error {
+	markerPath := c.GetCompletionPath(version, shellName) + unsupportedCompletionSuffix
+
+	if err := os.MkdirAll(filepath.Dir(markerPath), directoryPermission); err != nil {
+		return fmt.Errorf("failed to create completions directory: %w", err)
+	}
+
+	if err := os.WriteFile(markerPath, nil, filePermission); err != nil {
+		return fmt.Errorf("failed to record unsupported completion: %w", err)
+	}
+
+	return nil
+}
+
+// SetCurrentCompletion points the current completion symlink of a shell to the script of a version,
+// or removes it when the version has none.
+func (c *Config) SetCurrentCompletion(version, shellName string) error {
+	currentPath := c.GetCurrentCompletionPath(shellName)
+
+	// Remove old symlink if it exists (ignore error if it doesn't exist)
+	_ = os.Remove(currentPath)
+
+	completionPath := c.GetCompletionPath(version, shellName)
+	if _, err := os.Stat(completionPath); err != nil {
+		return nil //nolint:nilerr // No completion for this version
+	}
+
+	if err := os.Symlink(completionPath, currentPath); err != nil {
+		return fmt.Errorf("failed to create completion symlink: %w", err)
+	}
+
+	return nil
+}
@@ -13,8 +13,21 @@ const (
 	// AppName is the application name used for cache directories.
 	AppName = "glint-vm"
 	// VersionsDir is the subdirectory name for storing versions.
-	VersionsDir                     = "versions"
+	VersionsDir = "versions"
+	// StagingDir is the subdirectory name for in-progress installs.
+	StagingDir = "staging"
+	// LocksDir is the subdirectory name for per-version install locks.
+	LocksDir = "locks"
+	// PlatformsDir is the subdirectory name holding the cache of other platforms than the host.
+	PlatformsDir = "platforms"
+	// DocsDir is the subdirectory of a version keeping the documentation shipped in its release archive.
+	DocsDir = "docs"
+	// CompletionsDir is the subdirectory of a version holding the shell completions of its binary.
+	CompletionsDir = "completions"
+	// ReleaseIndexFile is the file name of the cached list of upstream releases.
+	ReleaseIndexFile                = "releases.json"
 	directoryPermission os.FileMode = 0o700
+	filePermission      os.FileMode = 0o600
 	windows                         = "windows"
 )
 
@@ -26,6 +39,10 @@ type Config struct {
 	OS string
 	// Arch is the architecture (amd64, arm64, etc.)
 	Arch string
+	// ARM is the ARM version (6 or 7) of arm targets, empty for other architectures.
+	ARM string
+	// foreign is set when OS/Arch were overridden with another platform than the host.
+	foreign bool
 }
 
 // New creates a new Config with detected values.
@@ -39,6 +56,7 @@ func New() (*Config, error) {
 		CacheDir: cacheDir,
 		OS:       runtime.GOOS,
 		Arch:     runtime.GOARCH,
+		ARM:      detectARM(),
 	}, nil
 }
 
@@ -65,9 +83,47 @@ func getCacheDir() (string, error) {
 	return filepath.Join(baseDir, AppName), nil
 }
 
+// IsHostPlatform reports whether the config targets the platform glint-vm runs on,
+// i.e. it wasn't retargeted to another platform with ForPlatform.
+func (c *Config) IsHostPlatform() bool {
+	return !c.foreign
+}
+
+// getPlatformRoot returns the cache root of the configured platform. Host binaries live at the
+// root of the cache, binaries of other platforms under platforms/<os>-<arch> so they never collide.
+func (c *Config) getPlatformRoot() string {
+	if c.IsHostPlatform() {
+		return c.CacheDir
+	}
+
+	return filepath.Join(c.CacheDir, PlatformsDir, c.GetPlatformString())
+}
+
+// ListPlatforms returns the platforms other than the host with a cache, e.g. linux-arm64, sorted.
+func (c *Config) ListPlatforms() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(c.CacheDir, PlatformsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read platforms directory: %w", err)
+	}
+
+	var platforms []string
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			platforms = append(platforms, entry.Name())
+		}
+	}
+
+	return platforms, nil
+}
+
 // GetVersionsDir returns the directory where all versions are cached.
 func (c *Config) GetVersionsDir() string {
-	return filepath.Join(c.CacheDir, VersionsDir)
+	return filepath.Join(c.getPlatformRoot(), VersionsDir)
 }
 
 // GetVersionDir returns the directory for a specific version.
@@ -75,14 +131,45 @@ func (c *Config) GetVersionDir(version string) string {
 	return filepath.Join(c.GetVersionsDir(), version)
 }
 
-// GetBinaryPath returns the full path to the golangci-lint binary for a specific version.
-func (c *Config) GetBinaryPath(version string) string {
-	binaryName := "golangci-lint"
+// GetStagingRoot returns the directory holding all in-progress installs.
+func (c *Config) GetStagingRoot() string {
+	return filepath.Join(c.getPlatformRoot(), StagingDir)
+}
+
+// GetStagingDir returns the directory where a version is downloaded and extracted
+// before being moved into the versions directory.
+func (c *Config) GetStagingDir(version string) string {
+	return filepath.Join(c.GetStagingRoot(), version)
+}
+
+// GetLockPath returns the path of the file locked while a version is being installed.
+func (c *Config) GetLockPath(version string) string {
+	return filepath.Join(c.getPlatformRoot(), LocksDir, version+".lock")
+}
+
+// GetReleaseIndexPath returns the path of the cached list of upstream releases.
+func (c *Config) GetReleaseIndexPath() string {
+	return filepath.Join(c.CacheDir, ReleaseIndexFile)
+}
+
+// BinaryName returns the golangci-lint binary file name for the configured OS.
+func (c *Config) BinaryName() string {
 	if c.OS == windows {
-		binaryName += ".exe"
+		return "golangci-lint.exe"
 	}
 
-	return filepath.Join(c.GetVersionDir(version), binaryName)
+	return "golangci-lint"
+}
+
+// GetBinaryPath returns the full path to the golangci-lint binary for a specific version.
+func (c *Config) GetBinaryPath(version string) string {
+	return filepath.Join(c.GetVersionDir(version), c.BinaryName())
+}
+
+// GetDocsDir returns the directory keeping the README, LICENSE and other files
+// shipped next to the binary in the release archive of a version.
+func (c *Config) GetDocsDir(version string) string {
+	return filepath.Join(c.GetVersionDir(version), DocsDir)
 }
 
 // EnsureVersionDir creates the version directory if it doesn't exist
@@ -100,20 +187,23 @@ func (c *Config) EnsureVersionDir(version string) error {
 
 // BinaryExists checks if the binary exists for a specific version.
 func (c *Config) BinaryExists(version string) bool {
-	binaryPath := c.GetBinaryPath(version)
+	return c.IsExecutableFile(c.GetBinaryPath(version))
+}
 
-	info, err := os.Stat(binaryPath)
+// IsExecutableFile checks if a path is a regular file executable on the configured platform.
+func (c *Config) IsExecutableFile(path string) bool {
+	info, err := os.Stat(path)
 	if err != nil {
 		return false
 	}
 	// Check if it's a regular file and executable
-	return info.Mode().IsRegular() && isExecutable(info)
+	return info.Mode().IsRegular() && c.isExecutable(info)
 }
 
 // isExecutable checks if a file is executable.
-func isExecutable(info os.FileInfo) bool {
+func (c *Config) isExecutable(info os.FileInfo) bool {
 	// On Unix systems, check if any execute bit is set
-	if runtime.GOOS != windows {
+	if runtime.GOOS != windows && c.OS != windows {
 		return info.Mode().Perm()&0o111 != 0
 	}
 	// On Windows, any regular file can be "executed" if it has the right extension
@@ -121,9 +211,9 @@ func isExecutable(info os.FileInfo) bool {
 }
 
 // GetPlatformString returns the platform string in the format expected by golangci-lint releases
-// Examples: "linux-amd64", "darwin-arm64", "windows-amd64".
+// Examples: "linux-amd64", "darwin-arm64", "windows-amd64", "linux-armv7".
 func (c *Config) GetPlatformString() string {
-	return fmt.Sprintf("%s-%s", c.OS, c.Arch)
+	return fmt.Sprintf("%s-%s", c.OS, c.AssetArch())
 }
 
 // GetCurrentDir returns the directory containing the current version symlink.
@@ -133,17 +223,17 @@ func (c *Config) GetCurrentDir() string {
 
 // GetCurrentBinaryPath returns the path to the current golangci-lint binary symlink.
 func (c *Config) GetCurrentBinaryPath() string {
-	binaryName := "golangci-lint"
-	if c.OS == windows {
-		binaryName += ".exe"
-	}
-
-	return filepath.Join(c.GetCurrentDir(), binaryName)
+	return filepath.Join(c.GetCurrentDir(), c.BinaryName())
 }
 
 // SetCurrentVersion manages the symlink to point to a specific version
 // It creates the current directory if needed, removes old symlink, and creates new one.
 func (c *Config) SetCurrentVersion(version string) error {
+	// Binaries of other platforms can't run here
+	if !c.IsHostPlatform() {
+		return fmt.Errorf("version %s for %s: %w", version, c.GetPlatformString(), ErrForeignPlatform)
+	}
+
 	// Ensure the version binary exists
 	if !c.BinaryExists(version) {
 		return fmt.Errorf("version %s: %w", version, ErrVersionNotInstalled)
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -173,6 +174,7 @@ func TestGetPlatformString(t *testing.T) {
 		name string
 		os   string
 		arch string
+		arm  string
 		want string
 	}{
 		{
@@ -193,6 +195,25 @@ func TestGetPlatformString(t *testing.T) {
 			arch: "amd64",
 			want: "windows-amd64",
 		},
+		{
+			name: "linux-armv7",
+			os:   "linux",
+			arch: "arm",
+			arm:  "7",
+			want: "linux-armv7",
+		},
+		{
+			name: "linux-arm without version",
+			os:   "linux",
+			arch: "arm",
+			want: "linux-armv6",
+		},
+		{
+			name: "linux-386",
+			os:   "linux",
+			arch: "386",
+			want: "linux-386",
+		},
 	}
 
 	for _, test := range tests {
@@ -203,6 +224,7 @@ func TestGetPlatformString(t *testing.T) {
 				CacheDir: "/test/cache",
 				OS:       test.os,
 				Arch:     test.arch,
+				ARM:      test.arm,
 			}
 
 			got := cfg.GetPlatformString()
@@ -670,3 +692,175 @@ func stringContains(s, substr string) bool {
 
 	return false
 }
+
+func TestGetStagingDirAndLockPath(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		CacheDir: "/test/cache",
+		OS:       "linux",
+		Arch:     "amd64",
+	}
+
+	if got, want := cfg.GetStagingDir(testVersion), filepath.Join("/test/cache", StagingDir, testVersion); got != want {
+		t.Errorf("GetStagingDir(%s) = %s, want %s", testVersion, got, want)
+	}
+
+	if got, want := cfg.GetLockPath(testVersion), filepath.Join("/test/cache", LocksDir, testVersion+".lock"); got != want {
+		t.Errorf("GetLockPath(%s) = %s, want %s", testVersion, got, want)
+	}
+
+	if got, want := cfg.GetReleaseIndexPath(), filepath.Join("/test/cache", ReleaseIndexFile); got != want {
+		t.Errorf("GetReleaseIndexPath() = %s, want %s", got, want)
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		platform   string
+		wantOS     string
+		wantArch   string
+		wantErrNil bool
+	}{
+		{platform: "linux/arm64", wantOS: "linux", wantArch: "arm64", wantErrNil: true},
+		{platform: "darwin-amd64", wantOS: "darwin", wantArch: "amd64", wantErrNil: true},
+		{platform: "linux/arm/v7", wantOS: "linux", wantArch: "armv7", wantErrNil: true},
+		{platform: "linux-armv6", wantOS: "linux", wantArch: "armv6", wantErrNil: true},
+		{platform: "linux"},
+		{platform: "/arm64"},
+		{platform: "linux/arm64/v8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			t.Parallel()
+
+			goos, goarch, err := ParsePlatform(tt.platform)
+			if tt.wantErrNil != (err == nil) {
+				t.Fatalf("ParsePlatform(%q) error = %v", tt.platform, err)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidPlatform) {
+				t.Errorf("ParsePlatform(%q) error = %v, want %v", tt.platform, err, ErrInvalidPlatform)
+			}
+
+			if goos != tt.wantOS || goarch != tt.wantArch {
+				t.Errorf("ParsePlatform(%q) = %s, %s, want %s, %s", tt.platform, goos, goarch, tt.wantOS, tt.wantArch)
+			}
+		})
+	}
+}
+
+func TestForPlatform(t *testing.T) {
+	t.Parallel()
+
+	host := &Config{CacheDir: t.TempDir(), OS: runtime.GOOS, Arch: runtime.GOARCH}
+
+	if same := host.ForPlatform(runtime.GOOS, runtime.GOARCH); !same.IsHostPlatform() || same.GetVersionsDir() != host.GetVersionsDir() {
+		t.Error("ForPlatform() with the host platform should keep the host layout")
+	}
+
+	foreign := host.ForPlatform("plan9", "riscv64")
+	if foreign.IsHostPlatform() {
+		t.Fatal("IsHostPlatform() = true for plan9/riscv64")
+	}
+
+	root := filepath.Join(host.CacheDir, PlatformsDir, "plan9-riscv64")
+
+	if got, want := foreign.GetVersionDir(testVersion), filepath.Join(root, VersionsDir, testVersion); got != want {
+		t.Errorf("GetVersionDir(%s) = %s, want %s", testVersion, got, want)
+	}
+
+	if got, want := foreign.GetStagingDir(testVersion), filepath.Join(root, StagingDir, testVersion); got != want {
+		t.Errorf("GetStagingDir(%s) = %s, want %s", testVersion, got, want)
+	}
+
+	if arm := host.ForPlatform("linux", "armv7"); arm.Arch != "arm" || arm.ARM != "7" || arm.GetPlatformString() != "linux-armv7" {
+		t.Errorf("ForPlatform(linux, armv7) = %s/%s v%s", arm.OS, arm.Arch, arm.ARM)
+	}
+
+	if err := foreign.SetCurrentVersion(testVersion); !errors.Is(err, ErrForeignPlatform) {
+		t.Errorf("SetCurrentVersion() error = %v, want %v", err, ErrForeignPlatform)
+	}
+
+	if err := foreign.EnsureVersionDir(testVersion); err != nil {
+		t.Fatalf("EnsureVersionDir() failed: %v", err)
+	}
+
+	if platforms, err := host.ListPlatforms(); err != nil || len(platforms) != 1 || platforms[0] != "plan9-riscv64" {
+		t.Errorf("ListPlatforms() = %v, %v, want [plan9-riscv64]", platforms, err)
+	}
+}
+
+func TestSetCurrentCompletion(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == windows {
+		t.Skip("Skipping symlink test on Windows")
+	}
+
+	cfg := &Config{
+		CacheDir: t.TempDir(),
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+	}
+
+	if err := os.MkdirAll(cfg.GetCurrentDir(), directoryPermission); err != nil {
+		t.Fatalf("Failed to create current directory: %v", err)
+	}
+
+	if err := cfg.WriteCompletion(testVersion, "bash", []byte("complete -F _golangci-lint golangci-lint\n")); err != nil {
+		t.Fatalf("WriteCompletion() failed: %v", err)
+	}
+
+	if info, err := os.Stat(cfg.GetCompletionPath(testVersion, "bash")); err != nil || info.Mode().Perm() != filePermission {
+		t.Errorf("completion script mode = %v (%v), want %v", info.Mode().Perm(), err, os.FileMode(filePermission))
+	}
+
+	if err := cfg.SetCurrentCompletion(testVersion, "bash"); err != nil {
+		t.Fatalf("SetCurrentCompletion() failed: %v", err)
+	}
+
+	target, err := os.Readlink(cfg.GetCurrentCompletionPath("bash"))
+	if err != nil || target != cfg.GetCompletionPath(testVersion, "bash") {
+		t.Errorf("current completion links to %q (%v), want %q", target, err, cfg.GetCompletionPath(testVersion, "bash"))
+	}
+
+	// A version without completion removes the link of the previous one
+	if err := cfg.SetCurrentCompletion("v1.20.0", "bash"); err != nil {
+		t.Fatalf("SetCurrentCompletion() failed: %v", err)
+	}
+
+	if _, err := os.Lstat(cfg.GetCurrentCompletionPath("bash")); !os.IsNotExist(err) {
+		t.Errorf("current completion should be removed, got %v", err)
+	}
+}
+
+func TestMarkCompletionUnsupported(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		CacheDir: t.TempDir(),
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+	}
+
+	if cfg.IsCompletionUnsupported(testVersion, "bash") {
+		t.Fatal("IsCompletionUnsupported() = true before marking")
+	}
+
+	if err := cfg.MarkCompletionUnsupported(testVersion, "bash"); err != nil {
+		t.Fatalf("MarkCompletionUnsupported() failed: %v", err)
+	}
+
+	if !cfg.IsCompletionUnsupported(testVersion, "bash") || cfg.IsCompletionUnsupported(testVersion, "zsh") {
+		t.Error("IsCompletionUnsupported() should only report the marked shell")
+	}
+
+	// The marker isn't mistaken for a script
+	if _, err := os.Stat(cfg.GetCompletionPath(testVersion, "bash")); !os.IsNotExist(err) {
+		t.Errorf("completion script should not exist, got %v", err)
+	}
+}
@@ -6,4 +6,10 @@ import "errors"
 var (
 	// ErrVersionNotInstalled is returned when a requested version is not installed.
 	ErrVersionNotInstalled = errors.New("version is not installed")
+
+	// ErrInvalidPlatform is returned when a platform isn't in os/arch form.
+	ErrInvalidPlatform = errors.New("invalid platform")
+
+	// ErrForeignPlatform is returned when activating a version installed for another platform than the host.
+	ErrForeignPlatform = errors.New("cannot activate a binary of another platform")
 )
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"golang.org/x/sys/cpu"
+)
+
+const (
+	arm = "arm"
+	// armAssetPrefix prefixes the ARM version in golangci-lint asset names (armv6, armv7).
+	armAssetPrefix = "armv"
+	// defaultARM is the ARM version assumed when it isn't known, the oldest golangci-lint publishes.
+	defaultARM = "6"
+)
+
+// ParsePlatform parses a platform in os/arch form, such as linux/arm64, and returns its OS and
+// golangci-lint asset architecture. ARM versions are given as linux/arm/v7 (as in container
+// platforms) or linux/armv7. The os-arch form used by asset names (linux-armv7) is accepted too.
+func ParsePlatform(platform string) (string, string, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) == 1 {
+		parts = strings.SplitN(platform, "-", 2)
+	}
+
+	invalid := fmt.Errorf("%w: %q, expected os/arch", ErrInvalidPlatform, platform)
+
+	switch {
+	case len(parts) == 3 && parts[1] == arm && strings.HasPrefix(parts[2], "v") && len(parts[2]) > 1:
+		parts = []string{parts[0], armAssetPrefix + strings.TrimPrefix(parts[2], "v")}
+	case len(parts) != 2:
+		return "", "", invalid
+	}
+
+	if parts[0] == "" || parts[1] == "" || strings.Contains(parts[1], "-") {
+		return "", "", invalid
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// ForPlatform returns a copy of the config targeting a platform. goarch is a GOARCH,
+// or an asset architecture carrying the ARM version such as armv7.
+func (c *Config) ForPlatform(goos, goarch string) *Config {
+	target := &Config{
+		CacheDir: c.CacheDir,
+		OS:       goos,
+		Arch:     goarch,
+	}
+
+	if version, ok := strings.CutPrefix(goarch, armAssetPrefix); ok {
+		target.Arch = arm
+		target.ARM = version
+	} else if goarch == arm && goarch == c.Arch {
+		target.ARM = c.ARM
+	}
+
+	host := &Config{OS: runtime.GOOS, Arch: runtime.GOARCH, ARM: detectARM()}
+	target.foreign = target.GetPlatformString() != host.GetPlatformString()
+
+	return target
+}
+
+// AssetArch returns the architecture name used by golangci-lint release assets. It is the GOARCH,
+// except for 32-bit ARM which is published per ARM version: armv6, armv7.
+// Other variables (GOAMD64, GO386, GOMIPS64) don't change the asset name.
+func (c *Config) AssetArch() string {
+	if c.Arch != arm {
+		return c.Arch
+	}
+
+	version := c.ARM
+	if version == "" {
+		version = defaultARM
+	}
+
+	return armAssetPrefix + version
+}
+
+// detectARM returns the ARM version of the host: GOARM when set, otherwise 7 when the CPU
+// supports VFPv3 as every ARMv7 core does. It returns an empty string on other architectures.
+func detectARM() string {
+	if runtime.GOARCH != arm {
+		return ""
+	}
+
+	if goarm := os.Getenv("GOARM"); goarm != "" {
+		// GOARM may carry a float ABI suffix: 7,softfloat
+		version, _, _ := strings.Cut(goarm, ",")
+
+		return version
+	}
+
+	if cpu.ARM.HasVFPv3 {
+		return "7"
+	}
+
+	return defaultARM
+}
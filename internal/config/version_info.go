@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// VersionInfoFile is the file, in a version directory, recording how the version was installed.
+const VersionInfoFile = ".glint-vm.json"
+
+// VersionInfo records how a cached version was installed, when it isn't from the native release archive.
+type VersionInfo struct {
+	// Platform is the platform of the binary when it differs from the cache's one, e.g. darwin-amd64.
+	Platform string `json:"platform,omitempty"`
+	// Emulated is set when the binary runs under emulation, such as Rosetta 2 on Apple Silicon.
+	Emulated bool `json:"emulated,omitempty"`
+	// SourceBuilt is set when the binary was built from source with the Go toolchain.
+	SourceBuilt bool `json:"source_built,omitempty"`
+	// GoVersion is the version of the Go toolchain that built the binary, e.g. go1.23.4.
+	GoVersion string `json:"go_version,omitempty"`
+}
+
+// WriteVersionInfo writes the install information of a version into its (staging) directory.
+func WriteVersionInfo(dir string, info VersionInfo) error {
+	content, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to encode version info: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, VersionInfoFile), content, filePermission); err != nil {
+		return fmt.Errorf("failed to write version info: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersionInfo returns the install information of a cached version. Versions installed from
+// their native release archive, or by older glint-vm releases, have none.
+func (c *Config) GetVersionInfo(version string) VersionInfo {
+	var info VersionInfo
+
+	content, err := os.ReadFile(filepath.Join(c.GetVersionDir(version), VersionInfoFile))
+	if err != nil {
+		return VersionInfo{}
+	}
+
+	if err := json.Unmarshal(content, &info); err != nil {
+		return VersionInfo{}
+	}
+
+	return info
+}
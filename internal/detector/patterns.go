@@ -111,6 +111,11 @@ func GetInstallVersionPattern() *VersionPattern {
 	return createPatterns().installVersion
 }
 
+// GetFilenamePattern returns the pattern for matching versions in file names and URLs.
+func GetFilenamePattern() *VersionPattern {
+	return createPatterns().filename
+}
+
 // ExtractVersion tries to extract a version string from the given text using the pattern.
 func (p *VersionPattern) ExtractVersion(text string) string {
 	matches := p.Regex.FindStringSubmatch(text)
@@ -521,3 +521,40 @@ lint:
 		})
 	}
 }
+
+func TestFilenamePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "release archive",
+			input: "golangci-lint-1.55.2-linux-amd64.tar.gz",
+			want:  "v1.55.2",
+		},
+		{
+			name:  "v-prefixed binary",
+			input: "golangci-lint-v2.1.0",
+			want:  "v2.1.0",
+		},
+		{
+			name:  "no version",
+			input: "golangci-lint",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GetFilenamePattern().ExtractVersion(tt.input)
+			if got != tt.want {
+				t.Errorf("ExtractVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package downloader
+
+import (
+	"context"
+	"sync"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+// Result is the outcome of installing one version with DownloadAll.
+type Result struct {
+	Version string
+	Err     error
+}
+
+// DownloadAll installs several versions, running at most jobs downloads at once.
+// Results are returned in the order of the (deduplicated) versions.
+func (d *Downloader) DownloadAll(ctx context.Context, versions []string, jobs int) []Result {
+	versions = uniqueVersions(versions)
+	jobs = max(1, min(jobs, len(versions)))
+
+	// Concurrent progress lines would overwrite each other
+	worker := d
+	if jobs > 1 {
+		quiet := *d
+		quiet.progress = nil
+		worker = &quiet
+	}
+
+	results := make([]Result, len(versions))
+	semaphore := make(chan struct{}, jobs)
+
+	var wg sync.WaitGroup
+
+	for i, version := range versions {
+		results[i].Version = version
+
+		wg.Go(func() {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i].Err = worker.Download(ctx, version)
+		})
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// uniqueVersions normalizes versions and removes duplicates, keeping the first occurrence.
+func uniqueVersions(versions []string) []string {
+	seen := make(map[string]bool, len(versions))
+	unique := make([]string, 0, len(versions))
+
+	for _, version := range versions {
+		version = config.NormalizeVersion(version)
+		if version == "" || seen[version] {
+			continue
+		}
+
+		seen[version] = true
+		unique = append(unique, version)
+	}
+
+	return unique
+}
@@ -0,0 +1,80 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownloadAll(t *testing.T) {
+	const okVersion, otherVersion, missingVersion = "v1.55.2", "v1.59.1", "v9.9.9"
+
+	files := map[string][]byte{}
+
+	for _, version := range []string{okVersion, otherVersion} {
+		archive := buildTarball(t, version, fakeBinary)
+		files[releaseAssetPath(version)] = archive
+		files[releaseAssetPath(version)+".sha256"] = []byte(sha256Hex(archive))
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			highest := maxInFlight.Load()
+			if current <= highest || maxInFlight.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	results := dl.DownloadAll(context.Background(), []string{"1.55.2", missingVersion, otherVersion, okVersion}, 2)
+
+	versions := make([]string, 0, len(results))
+	for _, result := range results {
+		versions = append(versions, result.Version)
+	}
+
+	if want := []string{okVersion, missingVersion, otherVersion}; !reflect.DeepEqual(versions, want) {
+		t.Fatalf("DownloadAll() versions = %v, want %v", versions, want)
+	}
+
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("DownloadAll() unexpected errors: %v, %v", results[0].Err, results[2].Err)
+	}
+
+	if !errors.Is(results[1].Err, ErrNoMirrors) {
+		t.Errorf("DownloadAll() error for %s = %v, want %v", missingVersion, results[1].Err, ErrNoMirrors)
+	}
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("max concurrent requests = %d, want <= 2", got)
+	}
+}
@@ -18,6 +18,8 @@ type CachedVersion struct {
 	Size       int64
 	ModTime    time.Time
 	IsComplete bool // Whether the binary exists and is executable
+	// Info records how the version was installed, e.g. emulated under Rosetta 2.
+	Info config.VersionInfo
 }
 
 // CacheManager manages cached golangci-lint versions.
@@ -32,9 +34,19 @@ func NewCacheManager() (*CacheManager, error) {
 		return nil, fmt.Errorf("failed to initialize config: %w", err)
 	}
 
+	return newCacheManager(cfg), nil
+}
+
+// NewCacheManagerFor creates a cache manager for the platform of a config, e.g. one from config.ForPlatform.
+func NewCacheManagerFor(cfg *config.Config) *CacheManager {
+	return newCacheManager(cfg)
+}
+
+// newCacheManager creates a cache manager sharing an existing config.
+func newCacheManager(cfg *config.Config) *CacheManager {
 	return &CacheManager{
 		config: cfg,
-	}, nil
+	}
 }
 
 // List returns all cached versions.
@@ -67,6 +79,7 @@ func (cm *CacheManager) List() ([]*CachedVersion, error) {
 			Path:       versionPath,
 			BinaryPath: binaryPath,
 			IsComplete: cm.config.BinaryExists(version),
+			Info:       cm.config.GetVersionInfo(version),
 		}
 
 		// Get binary info if it exists
@@ -220,7 +233,11 @@ func (cm *CacheManager) GetTotalSize() (int64, error) {
 
 // EnsureVersionDir ensures the directory for a version exists with proper permissions.
 func (cm *CacheManager) EnsureVersionDir(version string) error {
-	return fmt.Errorf("ensure version dir: %w", cm.config.EnsureVersionDir(version))
+	if err := cm.config.EnsureVersionDir(version); err != nil {
+		return fmt.Errorf("ensure version dir: %w", err)
+	}
+
+	return nil
 }
 
 // GetVersionDir returns the directory path for a version.
@@ -0,0 +1,57 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Changelog returns the releases after from, up to and including to, oldest first, with their
+// release notes. Prereleases are only included when to is one. The bounds may be given in any order.
+// Releases come from the cached release index, refreshed when it is older than its TTL.
+func (d *Downloader) Changelog(ctx context.Context, from, to string) ([]Release, error) {
+	lower, err := semver.NewVersion(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidVersionQuery, from, err)
+	}
+
+	upper, err := semver.NewVersion(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidVersionQuery, to, err)
+	}
+
+	if upper.LessThan(lower) {
+		lower, upper = upper, lower
+	}
+
+	releases, err := d.releases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	if !slices.ContainsFunc(releases, func(release Release) bool {
+		version, err := semver.NewVersion(release.TagName)
+
+		return err == nil && version.Equal(upper)
+	}) {
+		return nil, fmt.Errorf("%w: %s", ErrReleaseNotFound, upper.Original())
+	}
+
+	filter := ReleaseFilter{
+		Prerelease: upper.Prerelease() != "",
+		Match: func(release Release) bool {
+			version, err := semver.NewVersion(release.TagName)
+
+			return err == nil && version.GreaterThan(lower) && !version.GreaterThan(upper)
+		},
+	}
+
+	// APIs list releases by creation date, backports may come after newer majors
+	changes := filter.apply(releases)
+	sortReleasesNewestFirst(changes)
+	slices.Reverse(changes)
+
+	return changes, nil
+}
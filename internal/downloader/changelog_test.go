@@ -0,0 +1,54 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestChangelog(t *testing.T) {
+	api, _, _ := newIndexAPI(t, []Release{
+		{TagName: "v1.64.9", Body: "backport"},
+		{TagName: "v2.0.0", Body: "v2"},
+		{TagName: "v1.64.8-rc.1", Prerelease: true},
+		{TagName: "v1.64.8", Body: "fixes"},
+		{TagName: "v1.64.7", Body: "features"},
+		{TagName: "v1.64.6", Draft: true},
+		{TagName: "v1.64.5"},
+	})
+
+	dl := newTestDownloader(t, Options{Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}}})
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+		wantErr  error
+	}{
+		{name: "range", from: "v1.64.5", to: "v1.64.9", want: []string{"v1.64.7", "v1.64.8", "v1.64.9"}},
+		{name: "reversed bounds", from: "v1.64.8", to: "v1.64.5", want: []string{"v1.64.7", "v1.64.8"}},
+		{name: "across majors", from: "v1.64.8", to: "v2.0.0", want: []string{"v1.64.9", "v2.0.0"}},
+		{name: "same version", from: "v1.64.8", to: "v1.64.8"},
+		{name: "unknown target", from: "v1.64.5", to: "v1.99.0", wantErr: ErrReleaseNotFound},
+		{name: "invalid version", from: "main", to: "v1.64.8", wantErr: ErrInvalidVersionQuery},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // shares the downloader of newTestDownloader
+		t.Run(tt.name, func(t *testing.T) {
+			releases, err := dl.Changelog(context.Background(), tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Changelog() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var tags []string
+			for _, release := range releases {
+				tags = append(tags, release.TagName)
+			}
+
+			if !slices.Equal(tags, tt.want) {
+				t.Errorf("Changelog() = %v, want %v", tags, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package downloader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	// StrictEnvVar is the environment variable enabling strict checksum verification.
+	StrictEnvVar = "GLINT_VM_STRICT"
+
+	// maxChecksumFileSize bounds checksum files and manifests read into memory.
+	maxChecksumFileSize = 1024 * 1024
+	sha256HexLength     = 64
+)
+
+// verifyChecksum verifies the archive against the release checksums manifest,
+// falling back to the per-archive .sha256 file when the manifest is unavailable.
+// A missing or malformed checksum is only tolerated when checksums are not required.
+func (d *Downloader) verifyChecksum(ctx context.Context, archivePath string, mirror Mirror, version, goarch string) error {
+	expectedHash, source, err := d.fetchExpectedChecksum(ctx, mirror, version, d.config.OS, goarch)
+	if err != nil {
+		if d.requireChecksum || !isUnverifiable(err) {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: %v, skipping verification\n", err)
+
+		return nil
+	}
+
+	return verifyFileChecksum(archivePath, expectedHash, source)
+}
+
+// verifyFileChecksum compares the SHA-256 of a file against an expected hash.
+func verifyFileChecksum(filePath, expectedHash, source string) error {
+	actualHash, err := fileSHA256(filePath)
+	if err != nil {
+		return err
+	}
+
+	if actualHash != expectedHash {
+		return fmt.Errorf("%w: expected %s, got %s (from %s)", ErrChecksumMismatch, expectedHash, actualHash, source)
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Checksum verified (%s)\n", source)
+
+	return nil
+}
+
+// fetchExpectedChecksum returns the expected SHA-256 of a platform archive and the name of the file it came from.
+func (d *Downloader) fetchExpectedChecksum(
+	ctx context.Context,
+	mirror Mirror,
+	version, goos, goarch string,
+) (string, string, error) {
+	// Checksum listed by the release source itself
+	if mirror.sha256 != "" {
+		return validateChecksum(mirror.sha256, path.Base(mirror.BaseURL))
+	}
+
+	if manifestURL := mirror.ManifestURL(version, goos, goarch); manifestURL != "" {
+		manifest, err := d.fetchChecksumFile(ctx, manifestURL)
+		if err == nil {
+			source := path.Base(manifestURL)
+			name := assetName(version, goos, goarch)
+
+			hash, ok := findManifestChecksum(manifest, name)
+			if !ok {
+				return "", source, fmt.Errorf("%w: %s not listed in %s", ErrChecksumNotFound, name, source)
+			}
+
+			return validateChecksum(hash, source)
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: Checksums manifest not available (%v), trying per-file checksum\n", err)
+	}
+
+	checksumURL := mirror.ChecksumURL(version, goos, goarch)
+
+	source := path.Base(checksumURL)
+
+	content, err := d.fetchChecksumFile(ctx, checksumURL)
+	if err != nil {
+		return "", source, fmt.Errorf("%w: %w", ErrChecksumUnavailable, err)
+	}
+
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return "", source, fmt.Errorf("%w: %s is empty", ErrMalformedChecksum, source)
+	}
+
+	return validateChecksum(fields[0], source)
+}
+
+// validateChecksum checks that a hash is a hex-encoded SHA-256 and normalizes it to lowercase.
+func validateChecksum(hash, source string) (string, string, error) {
+	hash = strings.ToLower(hash)
+
+	if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha256HexLength {
+		return "", source, fmt.Errorf("%w: %q in %s is not a SHA-256", ErrMalformedChecksum, hash, source)
+	}
+
+	return hash, source, nil
+}
+
+// isUnverifiable reports whether a checksum error means the archive could not be verified,
+// as opposed to having been proven wrong.
+func isUnverifiable(err error) bool {
+	return errors.Is(err, ErrChecksumUnavailable) || errors.Is(err, ErrMalformedChecksum)
+}
+
+// fetchChecksumFile downloads a small checksum file into memory, retrying transient failures.
+func (d *Downloader) fetchChecksumFile(ctx context.Context, url string) ([]byte, error) {
+	var content []byte
+
+	err := d.retry.do(ctx, func() error {
+		var err error
+
+		content, err = d.fetchChecksumFileOnce(ctx, url)
+
+		return err
+	})
+
+	return content, err
+}
+
+// fetchChecksumFileOnce downloads a small checksum file into memory.
+func (d *Downloader) fetchChecksumFileOnce(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	//nolint:gosec // URL is constructed from configured mirrors and validated version
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRequestFailed, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(url, resp)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checksum: %w: %w", errTransferFailed, err)
+	}
+
+	return content, nil
+}
+
+// findManifestChecksum finds the hash of a file in a sha256sum-style manifest
+// ("<hash>  <filename>" per line, filename optionally prefixed with "*").
+func findManifestChecksum(manifest []byte, filename string) (string, bool) {
+	for line := range strings.Lines(string(manifest)) {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		if strings.TrimPrefix(fields[1], "*") == filename {
+			return fields[0], true
+		}
+	}
+
+	return "", false
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of a file.
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath) //nolint:gosec // Path is internally controlled
+	if err != nil {
+		return "", fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to calculate checksum: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,173 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFindManifestChecksum(t *testing.T) {
+	t.Parallel()
+
+	manifest := []byte("AAAA  golangci-lint-1.55.2-linux-amd64.tar.gz\n" +
+		"bbbb *golangci-lint-1.55.2-darwin-arm64.tar.gz\n" +
+		"malformed line\n")
+
+	tests := []struct {
+		filename string
+		want     string
+		wantOK   bool
+	}{
+		{filename: "golangci-lint-1.55.2-linux-amd64.tar.gz", want: "AAAA", wantOK: true},
+		{filename: "golangci-lint-1.55.2-darwin-arm64.tar.gz", want: "bbbb", wantOK: true},
+		{filename: "golangci-lint-1.55.2-windows-amd64.zip", wantOK: false},
+	}
+
+	for _, test := range tests {
+		got, ok := findManifestChecksum(manifest, test.filename)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("findManifestChecksum(%s) = %q, %v, want %q, %v", test.filename, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+	name := assetName(testVersion, runtime.GOOS, runtime.GOARCH)
+	otherHash := strings.Repeat("0", 64)
+
+	tests := []struct {
+		name       string
+		files      map[string][]byte
+		strict     bool
+		wantErr    error
+		wantSource string
+	}{
+		{
+			name: "manifest takes precedence",
+			files: map[string][]byte{
+				manifestPath(testVersion):                 []byte(sha256Hex(archive) + "  " + name + "\n"),
+				releaseAssetPath(testVersion) + ".sha256": []byte(otherHash),
+			},
+			wantSource: "checksums.txt",
+		},
+		{
+			name: "manifest mismatch",
+			files: map[string][]byte{
+				manifestPath(testVersion): []byte(otherHash + "  " + name + "\n"),
+			},
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name: "archive missing from manifest",
+			files: map[string][]byte{
+				manifestPath(testVersion):                 []byte(otherHash + "  another-file.tar.gz\n"),
+				releaseAssetPath(testVersion) + ".sha256": []byte(sha256Hex(archive)),
+			},
+			wantErr: ErrChecksumNotFound,
+		},
+		{
+			name: "fallback to per-file checksum",
+			files: map[string][]byte{
+				releaseAssetPath(testVersion) + ".sha256": []byte(sha256Hex(archive)),
+			},
+			wantSource: ".sha256",
+		},
+		{
+			name:  "no checksum at all",
+			files: map[string][]byte{},
+		},
+		{
+			name: "empty per-file checksum",
+			files: map[string][]byte{
+				releaseAssetPath(testVersion) + ".sha256": {},
+			},
+		},
+		{
+			name:    "strict: no checksum at all",
+			files:   map[string][]byte{},
+			strict:  true,
+			wantErr: ErrChecksumUnavailable,
+		},
+		{
+			name: "strict: empty per-file checksum",
+			files: map[string][]byte{
+				releaseAssetPath(testVersion) + ".sha256": {},
+			},
+			strict:  true,
+			wantErr: ErrMalformedChecksum,
+		},
+		{
+			name: "strict: malformed manifest entry",
+			files: map[string][]byte{
+				manifestPath(testVersion): []byte("not-a-hash  " + name + "\n"),
+			},
+			strict:  true,
+			wantErr: ErrMalformedChecksum,
+		},
+		{
+			name: "strict: verified",
+			files: map[string][]byte{
+				manifestPath(testVersion): []byte(strings.ToUpper(sha256Hex(archive)) + "  " + name + "\n"),
+			},
+			strict:     true,
+			wantSource: "checksums.txt",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newReleaseServer(t, test.files)
+
+			mirrors, err := ParseMirrors([]string{server.URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{Mirrors: mirrors, RequireChecksum: test.strict})
+
+			archivePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+			if err := os.WriteFile(archivePath, archive, filePermission); err != nil {
+				t.Fatalf("failed to write archive: %v", err)
+			}
+
+			_, source, _ := dl.fetchExpectedChecksum(context.Background(), mirrors[0], testVersion, runtime.GOOS, runtime.GOARCH)
+			if test.wantSource != "" && !strings.HasSuffix(source, test.wantSource) {
+				t.Errorf("checksum source = %q, want suffix %q", source, test.wantSource)
+			}
+
+			err = dl.verifyChecksum(context.Background(), archivePath, mirrors[0], testVersion, dl.config.AssetArch())
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("verifyChecksum() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDownload_StrictRemovesUnverifiedVersion(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+
+	server := newReleaseServer(t, map[string][]byte{
+		releaseAssetPath(testVersion): archive,
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors, RequireChecksum: true})
+
+	err = dl.Download(context.Background(), testVersion)
+	if !errors.Is(err, ErrChecksumUnavailable) {
+		t.Fatalf("Download() error = %v, want %v", err, ErrChecksumUnavailable)
+	}
+
+	if _, err := os.Stat(dl.cacheManager.GetVersionDir(testVersion)); !os.IsNotExist(err) {
+		t.Error("version directory should be removed in strict mode")
+	}
+}
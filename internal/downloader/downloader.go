@@ -5,51 +5,129 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/youkoulayley/glint-vm/internal/config"
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
 )
 
 const (
-	gitHubReleasesURL                = "https://github.com/golangci/golangci-lint/releases/download"
 	downloadTimeout                  = 10 * time.Minute
+	metadataTimeout                  = 30 * time.Second
 	maxExtractSize                   = 500 * 1024 * 1024
 	executablePermission os.FileMode = 0o755
+	filePermission       os.FileMode = 0o600
+	directoryPermission  os.FileMode = 0o700
+
+	tarGzExtension = "tar.gz"
+	zipExtension   = "zip"
 )
 
+// KeepDocsEnvVar is the environment variable keeping the docs shipped in release archives.
+const KeepDocsEnvVar = "GLINT_VM_KEEP_DOCS"
+
+// Options configures a Downloader.
+type Options struct {
+	// Mirrors are tried in order when downloading. Defaults to downloading from Source.
+	Mirrors []Mirror
+	// Source lists releases and locates their assets. Defaults to DefaultSource.
+	Source ReleaseSource
+	// RequireChecksum makes downloads fail when the archive can't be verified.
+	RequireChecksum bool
+	// Lock pins the expected archive checksums, replacing the ones served by mirrors.
+	Lock *lockfile.Lockfile
+	// Frozen makes downloads fail when Lock is missing or doesn't cover the requested version and platform.
+	Frozen bool
+	// Retry configures retries of transient HTTP failures. Zero values use DefaultRetryPolicy.
+	Retry RetryPolicy
+	// TLS configures extra CAs and the client certificate used for all requests.
+	TLS TLSOptions
+	// GitHubToken authenticates GitHub API calls, raising the rate limit.
+	GitHubToken string
+	// Platform is the os/arch the binaries are installed for. Defaults to the host.
+	// Binaries of other platforms are cached separately and cannot be activated.
+	Platform string
+	// KeepDocs keeps the README, LICENSE and other files shipped next to the binary
+	// in release archives, in the docs directory of each version.
+	KeepDocs bool
+	// RosettaFallback installs darwin-amd64 binaries on darwin/arm64 when a release has
+	// no darwin-arm64 archive, to run them under Rosetta 2. They are recorded as emulated.
+	RosettaFallback bool
+	// BuildFromSource builds golangci-lint with the Go toolchain when a release has no
+	// archive for the platform. Such versions are recorded as source-built.
+	BuildFromSource bool
+}
+
 // Downloader handles downloading golangci-lint binaries.
 type Downloader struct {
 	config       *config.Config
 	cacheManager *CacheManager
 	httpClient   *http.Client
+	mirrors      []Mirror
+	source       ReleaseSource
+	progress     io.Writer // nil disables progress reporting
+
+	requireChecksum bool
+	lock            *lockfile.Lockfile
+	frozen          bool
+	retry           RetryPolicy
+	githubToken     string
+	rosettaFallback bool
+	keepDocs        bool
+	buildFromSource bool
 }
 
 // NewDownloader creates a new downloader.
-func NewDownloader() (*Downloader, error) {
+func NewDownloader(opts Options) (*Downloader, error) {
 	cfg, err := config.New()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize config: %w", err)
 	}
 
-	cacheManager, err := NewCacheManager()
+	if opts.Platform != "" {
+		goos, goarch, err := config.ParsePlatform(opts.Platform)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg = cfg.ForPlatform(goos, goarch)
+	}
+
+	cacheManager := newCacheManager(cfg)
+
+	httpClient, err := newHTTPClient(opts.TLS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize cache manager: %w", err)
+		return nil, err
+	}
+
+	source := opts.Source
+	if source == nil {
+		source = DefaultSource()
 	}
 
 	return &Downloader{
-		config:       cfg,
-		cacheManager: cacheManager,
-		httpClient: &http.Client{
-			Timeout: downloadTimeout,
-		},
+		config:          cfg,
+		cacheManager:    cacheManager,
+		httpClient:      httpClient,
+		mirrors:         opts.Mirrors,
+		source:          source,
+		progress:        progressOutput(),
+		requireChecksum: opts.RequireChecksum,
+		lock:            opts.Lock,
+		frozen:          opts.Frozen,
+		retry:           opts.Retry.withDefaults(),
+		githubToken:     opts.GitHubToken,
+		rosettaFallback: opts.RosettaFallback,
+		keepDocs:        opts.KeepDocs,
+		buildFromSource: opts.BuildFromSource,
 	}, nil
 }
 
@@ -57,175 +135,444 @@ func NewDownloader() (*Downloader, error) {
 func (d *Downloader) Download(ctx context.Context, version string) error {
 	version = config.NormalizeVersion(version)
 
+	// Frozen mode refuses versions the lockfile doesn't cover, even when already cached
+	if d.frozen {
+		if _, err := d.lockedChecksum(version); err != nil {
+			return err
+		}
+	}
+
 	// Check if already cached
 	if d.cacheManager.IsCached(version) {
 		return nil // Already downloaded
 	}
 
-	// Construct download URL
-	archiveURL := d.getDownloadURL(version)
-	checksumURL := archiveURL + ".sha256"
+	d.sweepStaging()
+
+	unlock, err := d.acquireInstallLock(ctx, version)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	// Another process may have installed it while we were waiting for the lock
+	if d.cacheManager.IsCached(version) {
+		return nil
+	}
+
+	lockedChecksum, err := d.lockedChecksum(version)
+	if err != nil {
+		return err
+	}
 
 	fmt.Fprintf(os.Stderr, "Downloading golangci-lint %s...\n", version)
-	fmt.Fprintf(os.Stderr, "URL: %s\n", archiveURL)
 
-	// Create version directory
-	err := d.cacheManager.EnsureVersionDir(version)
+	stagingDir, err := d.prepareStagingDir(version)
 	if err != nil {
-		return fmt.Errorf("failed to create version directory: %w", err)
+		return err
 	}
 
-	versionDir := d.cacheManager.GetVersionDir(version)
-	archivePath := filepath.Join(versionDir, "archive.tar.gz")
+	err = d.install(ctx, version, stagingDir, lockedChecksum)
+	if err != nil {
+		// Keep the staging directory only when a partial download can be resumed
+		if ctx.Err() != nil || !hasResumableDownload(stagingDir) {
+			_ = os.RemoveAll(stagingDir)
+		}
+
+		return err
+	}
+
+	_ = os.RemoveAll(stagingDir)
+
+	fmt.Fprintf(os.Stderr, "✓ Successfully installed golangci-lint %s\n", version)
+	fmt.Fprintf(os.Stderr, "  Location: %s\n", d.cacheManager.GetBinaryPath(version))
+
+	return nil
+}
+
+// install downloads, verifies and extracts a version in its staging directory,
+// then atomically moves the result into the versions directory.
+func (d *Downloader) install(ctx context.Context, version, stagingDir, lockedChecksum string) error {
+	if source, ok := d.source.(binarySource); ok && len(d.mirrors) == 0 {
+		return d.installFromSource(ctx, source, version, stagingDir, lockedChecksum)
+	}
+
+	archivePath := filepath.Join(stagingDir, "archive."+archiveExtension(d.config.OS))
+	goarch := d.config.AssetArch()
+
+	var info config.VersionInfo
+
+	// Download archive from the first mirror that serves it
+	mirror, err := d.downloadFromMirrors(ctx, version, goarch, archivePath)
+
+	// Releases predating Apple Silicon run under Rosetta 2
+	if platform, ok := d.emulatedPlatform(); ok && errors.Is(err, ErrAssetNotFound) {
+		fmt.Fprintf(os.Stderr, "Warning: %v, falling back to %s under Rosetta 2\n", err, platform)
+
+		goarch = emulatedArch
+		info = config.VersionInfo{Platform: platform, Emulated: true}
+
+		lockedChecksum, err = d.lockedChecksumFor(version, platform)
+		if err != nil {
+			return err
+		}
+
+		mirror, err = d.downloadFromMirrors(ctx, version, goarch, archivePath)
+	} else if err == nil && d.pinsEmulatedOnly(version) {
+		// The release has a native archive after all, which the lockfile doesn't pin
+		lockedChecksum, err = d.lockedChecksumFor(version, d.config.GetPlatformString())
+		if err != nil {
+			return err
+		}
+	}
+
+	// Platforms without release archives build golangci-lint from source
+	if d.buildFromSource && errors.Is(err, ErrAssetNotFound) {
+		fmt.Fprintf(os.Stderr, "Warning: %v, building it from source\n", err)
+
+		return d.installFromSource(ctx, newGoSource(defaultGoModule), version, stagingDir, lockedChecksum)
+	}
 
-	// Download archive
-	err = d.downloadFile(ctx, archiveURL, archivePath)
 	if err != nil {
 		return fmt.Errorf("failed to download archive: %w", err)
 	}
 
-	// Download and verify checksum
-	err = d.verifyChecksum(ctx, archivePath, checksumURL)
+	// Verify against the lockfile, or the checksum from the same mirror
+	if lockedChecksum != "" {
+		err = verifyFileChecksum(archivePath, lockedChecksum, lockfile.FileName)
+	} else {
+		err = d.verifyChecksum(ctx, archivePath, mirror, version, goarch)
+	}
+
 	if err != nil {
-		// Checksum verification failed, clean up
-		_ = os.RemoveAll(versionDir)
+		// The archive is not trustworthy, don't resume from it
+		_ = os.Remove(archivePath)
 
 		return fmt.Errorf("checksum verification failed: %w", err)
 	}
 
 	// Extract archive
-	err = d.extractArchive(archivePath, versionDir)
-	if err != nil {
-		_ = os.RemoveAll(versionDir)
+	extractDir := filepath.Join(stagingDir, "extract")
+
+	if err := os.Mkdir(extractDir, directoryPermission); err != nil {
+		return fmt.Errorf("failed to create extraction directory: %w", err)
+	}
 
+	err = d.extractArchive(archivePath, extractDir, version, goarch)
+	if err != nil {
 		return fmt.Errorf("failed to extract archive: %w", err)
 	}
 
-	// Remove archive file
-	_ = os.Remove(archivePath)
+	if info != (config.VersionInfo{}) {
+		if err := config.WriteVersionInfo(extractDir, info); err != nil {
+			return err
+		}
+	}
+
+	return d.commitVersion(ctx, version, goarch, extractDir)
+}
+
+// checkBinarySource refuses sources serving binaries rather than release archives in frozen
+// or strict mode, as neither the lockfile nor a checksums manifest can verify what they install.
+// Otherwise, a lockfile pinning the version only gets a warning.
+func (d *Downloader) checkBinarySource(source binarySource, lockedChecksum string) error {
+	if d.frozen || d.requireChecksum {
+		return fmt.Errorf("%w: %s serves no release archive to verify in frozen or strict mode",
+			ErrChecksumUnavailable, source.Name())
+	}
+
+	if lockedChecksum != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s pins archive checksums, not applicable to %s\n",
+			lockfile.FileName, source.Name())
+	}
+
+	return nil
+}
+
+// installFromSource installs a version from a source serving binaries rather than archives.
+func (d *Downloader) installFromSource(ctx context.Context, source binarySource, version, stagingDir, lockedChecksum string) error {
+	if err := d.checkBinarySource(source, lockedChecksum); err != nil {
+		return err
+	}
+
+	extractDir := filepath.Join(stagingDir, "extract")
+
+	if err := os.Mkdir(extractDir, directoryPermission); err != nil {
+		return fmt.Errorf("failed to create extraction directory: %w", err)
+	}
+
+	if err := source.installBinary(ctx, d, version, stagingDir, extractDir); err != nil {
+		return fmt.Errorf("failed to install from %s: %w", source.Name(), err)
+	}
 
-	// Verify binary exists and is executable
-	if !d.cacheManager.IsCached(version) {
-		_ = os.RemoveAll(versionDir)
+	return d.commitVersion(ctx, version, d.config.AssetArch(), extractDir)
+}
 
+// commitVersion verifies the binary extracted in a staging directory, then atomically moves it into
+// the versions directory. An installed version is only replaced once its replacement passed
+// verification, and is kept otherwise. goarch is the architecture of the installed asset.
+func (d *Downloader) commitVersion(ctx context.Context, version, goarch, extractDir string) error {
+	// Verify binary exists, is executable and matches the requested version
+	binaryPath := filepath.Join(extractDir, d.config.BinaryName())
+	if !d.config.IsExecutableFile(binaryPath) {
 		return ErrBinaryNotFound
 	}
 
-	fmt.Fprintf(os.Stderr, "✓ Successfully installed golangci-lint %s\n", version)
-	fmt.Fprintf(os.Stderr, "  Location: %s\n", d.cacheManager.GetBinaryPath(version))
+	if err := d.verifyBinary(ctx, binaryPath, version, goarch); err != nil {
+		return fmt.Errorf("binary of %s rejected: %w", version, err)
+	}
+
+	versionDir := d.cacheManager.GetVersionDir(version)
+
+	if err := os.MkdirAll(filepath.Dir(versionDir), directoryPermission); err != nil {
+		return fmt.Errorf("failed to create versions directory: %w", err)
+	}
+
+	// Move the previous install aside (or an incomplete one left by an older glint-vm),
+	// to restore it if the new one can't take its place
+	previousDir := extractDir + ".previous"
+
+	err := os.Rename(versionDir, previousDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace installed version: %w", err)
+	}
+
+	replaced := err == nil
+
+	if err := os.Rename(extractDir, versionDir); err != nil {
+		if replaced {
+			_ = os.Rename(previousDir, versionDir)
+		}
+
+		return fmt.Errorf("failed to install version: %w", err)
+	}
+
+	if replaced {
+		_ = os.RemoveAll(previousDir)
+	}
 
 	return nil
 }
 
-// getDownloadURL constructs the download URL for a version.
-func (d *Downloader) getDownloadURL(version string) string {
-	platform := d.config.GetPlatformString()
-	// Example: https://github.com/golangci/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz
-	versionWithoutV := strings.TrimPrefix(version, "v")
-	filename := fmt.Sprintf("golangci-lint-%s-%s.tar.gz", versionWithoutV, platform)
+// hasResumableDownload reports whether a staging directory holds a partial download.
+func hasResumableDownload(stagingDir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(stagingDir, "*"+partialSuffix))
+	for _, match := range matches {
+		if fileSize(match) > 0 {
+			return true
+		}
+	}
 
-	return fmt.Sprintf("%s/%s/%s", gitHubReleasesURL, version, filename)
+	return false
 }
 
-// downloadFile downloads a file from URL to destination.
-func (d *Downloader) downloadFile(ctx context.Context, url, dest string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// archiveExtension returns the release archive format for an OS.
+// golangci-lint ships zip archives for Windows and tarballs everywhere else.
+func archiveExtension(goos string) string {
+	if goos == "windows" {
+		return zipExtension
 	}
 
-	//nolint:gosec // URL is constructed from hardcoded GitHub releases URL and validated version
-	resp, err := d.httpClient.Do(req)
+	return tarGzExtension
+}
+
+// progressOutput returns stderr when it is a terminal, nil otherwise.
+func progressOutput() io.Writer {
+	if isTerminal(os.Stderr) {
+		return os.Stderr
+	}
+
+	return nil
+}
+
+// downloadFromMirrors downloads the archive from the first mirror that serves it.
+// Transient failures are retried first; a mirror answering 404, 429 or 5xx, or not
+// answering at all, then falls back to the next one.
+func (d *Downloader) downloadFromMirrors(ctx context.Context, version, goarch, dest string) (Mirror, error) {
+	mirrors, err := d.assetMirrors(ctx, version, d.config.OS, goarch)
 	if err != nil {
-		return fmt.Errorf("HTTP request failed: %w", err)
+		return Mirror{}, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
+	var errs []error
+
+	for _, mirror := range mirrors {
+		// Example: https://github.com/golangci/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz
+		archiveURL := mirror.ArchiveURL(version, d.config.OS, goarch)
+
+		fmt.Fprintf(os.Stderr, "URL: %s\n", archiveURL)
+
+		err := d.retry.do(ctx, func() error {
+			return d.downloadFile(ctx, archiveURL, dest)
+		})
+		if err == nil {
+			return mirror, nil
+		}
+
+		if !shouldFallback(ctx, err) {
+			return Mirror{}, err
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: mirror %s failed: %v\n", mirror.BaseURL, err)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: HTTP %d: %s", ErrHTTPRequest, resp.StatusCode, resp.Status)
+		errs = append(errs, err)
 	}
 
-	out, err := os.Create(dest) //nolint:gosec // Path is internally controlled
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	// Tell a missing platform apart from a mirror missing the release
+	if allNotFound(errs) {
+		if err := d.missingAssetError(ctx, mirrors, version, goarch); err != nil {
+			return Mirror{}, err
+		}
 	}
 
-	defer func() { _ = out.Close() }()
+	return Mirror{}, fmt.Errorf("%w: %w", ErrNoMirrors, errors.Join(errs...))
+}
 
-	// Copy with progress (simple version without progress bar for now)
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// shouldFallback reports whether a failed request may be retried on another mirror.
+func shouldFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
 	}
 
-	return nil
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	// Server errors, rate limits and transport errors (DNS, connection refused, ...) are worth another mirror.
+	return isTransient(err) || (statusErr != nil && statusErr.StatusCode >= http.StatusInternalServerError)
 }
 
-// verifyChecksum downloads the checksum file and verifies the archive.
-func (d *Downloader) verifyChecksum(ctx context.Context, archivePath, checksumURL string) error {
-	// Download checksum file
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumURL, http.NoBody)
+// downloadFile downloads a file from URL to destination.
+// Data is written to a ".partial" file first; an existing partial file is
+// resumed with a Range request when the server supports it.
+func (d *Downloader) downloadFile(ctx context.Context, url, dest string) error {
+	partialPath := dest + partialSuffix
+	validatorPath := partialPath + validatorSuffix
+
+	offset := fileSize(partialPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Warning: Could not create checksum request, skipping verification")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
 
-		return nil
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+
+		if validator := readValidator(validatorPath); validator != "" {
+			req.Header.Set("If-Range", validator)
+		}
 	}
 
-	//nolint:gosec // URL is constructed from hardcoded GitHub releases URL and validated version
+	//nolint:gosec // URL is constructed from configured mirrors and validated version
 	resp, err := d.httpClient.Do(req)
 	if err != nil {
-		// Checksum file might not exist for all versions, skip verification
-		fmt.Fprintln(os.Stderr, "Warning: Could not download checksum file, skipping verification")
-
-		return nil
+		return fmt.Errorf("%w: %w", errRequestFailed, err)
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		// Checksum file doesn't exist, skip verification
-		fmt.Fprintln(os.Stderr, "Warning: Checksum file not available, skipping verification")
+	flags := os.O_CREATE | os.O_WRONLY
 
-		return nil
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !rangeStartsAt(resp.Header.Get("Content-Range"), offset) {
+			return fmt.Errorf("%w: unexpected Content-Range %q", ErrHTTPRequest, resp.Header.Get("Content-Range"))
+		}
+
+		fmt.Fprintf(os.Stderr, "Resuming download at %d bytes\n", offset)
+
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// Full content: either a fresh download, or the server ignored the range.
+		flags |= os.O_TRUNC
+
+		writeValidator(validatorPath, resp.Header)
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset == 0 {
+			return newStatusError(url, resp)
+		}
+
+		// The partial file doesn't match the remote file anymore, start over.
+		_ = os.Remove(partialPath)
+		_ = os.Remove(validatorPath)
+
+		_ = resp.Body.Close()
+
+		return d.downloadFile(ctx, url, dest)
+	default:
+		return newStatusError(url, resp)
 	}
 
-	// Read expected checksum
-	expectedChecksum, err := io.ReadAll(resp.Body)
+	out, err := os.OpenFile(partialPath, flags, filePermission) //nolint:gosec // Path is internally controlled
 	if err != nil {
-		return fmt.Errorf("failed to read checksum: %w", err)
+		return fmt.Errorf("failed to create file: %w", err)
 	}
 
-	expectedHash := strings.TrimSpace(strings.Fields(string(expectedChecksum))[0])
+	var writer io.Writer = out
 
-	// Calculate actual checksum
-	file, err := os.Open(archivePath) //nolint:gosec // Path is internally controlled
-	if err != nil {
-		return fmt.Errorf("failed to open archive: %w", err)
+	if d.progress != nil {
+		if flags&os.O_APPEND == 0 {
+			offset = 0
+		}
+
+		total := int64(-1)
+		if resp.ContentLength >= 0 {
+			total = offset + resp.ContentLength
+		}
+
+		progress := newProgressWriter(d.progress, offset, total)
+		defer progress.Finish()
+
+		writer = io.MultiWriter(out, progress)
 	}
 
-	defer func() { _ = file.Close() }()
+	_, err = io.Copy(writer, resp.Body)
+	if err != nil {
+		_ = out.Close()
 
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
+		return fmt.Errorf("failed to write file: %w: %w", errTransferFailed, err)
 	}
 
-	actualHash := hex.EncodeToString(hash.Sum(nil))
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
 
-	if actualHash != expectedHash {
-		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expectedHash, actualHash)
+	if err := os.Rename(partialPath, dest); err != nil {
+		return fmt.Errorf("failed to finalize download: %w", err)
 	}
 
-	fmt.Fprintln(os.Stderr, "✓ Checksum verified")
+	_ = os.Remove(validatorPath)
 
 	return nil
 }
 
-// extractArchive extracts a tar.gz archive to destination directory.
-func (d *Downloader) extractArchive(archivePath, destDir string) error {
+// extractArchive extracts the golangci-lint binary from a release archive to destination directory.
+// goarch is the architecture of the archive, which names the directory holding the binary.
+func (d *Downloader) extractArchive(archivePath, destDir, version, goarch string) error {
 	fmt.Fprintln(os.Stderr, "Extracting archive...")
 
+	expected := archiveBinaryPath(version, d.config.OS+"-"+goarch, d.config.BinaryName())
+
+	if strings.HasSuffix(archivePath, "."+zipExtension) {
+		return d.extractZip(archivePath, destDir, expected)
+	}
+
+	return d.extractTarGz(archivePath, destDir, expected)
+}
+
+// archiveBinaryPath returns the path of the binary in a release archive,
+// e.g. golangci-lint-1.55.2-linux-amd64/golangci-lint.
+func archiveBinaryPath(version, platform, binaryName string) string {
+	return "golangci-lint-" + strings.TrimPrefix(version, "v") + "-" + platform + "/" + binaryName
+}
+
+// extractTarGz extracts the golangci-lint binary from a tar.gz archive to destination directory.
+// Only the entry at the expected path is extracted, along with the files next to it when docs are kept;
+// archives holding links or special files are refused.
+func (d *Downloader) extractTarGz(archivePath, destDir, expected string) error {
 	file, err := os.Open(archivePath) //nolint:gosec // Path is internally controlled
 	if err != nil {
 		return fmt.Errorf("failed to open archive: %w", err)
@@ -241,6 +588,8 @@ func (d *Downloader) extractArchive(archivePath, destDir string) error {
 	defer func() { _ = gzr.Close() }()
 
 	reader := tar.NewReader(gzr)
+	target := filepath.Join(destDir, path.Base(expected))
+	found := false
 
 	for {
 		header, err := reader.Next()
@@ -252,52 +601,129 @@ func (d *Downloader) extractArchive(archivePath, destDir string) error {
 			return fmt.Errorf("failed to read tar header: %w", err)
 		}
 
-		// Only extract the golangci-lint binary
-		if !strings.HasSuffix(header.Name, "golangci-lint") && !strings.HasSuffix(header.Name, "golangci-lint.exe") {
-			continue
+		if err := checkTarEntry(header); err != nil {
+			return err
 		}
 
-		if header.Typeflag == tar.TypeReg {
-			target := filepath.Join(destDir, filepath.Base(header.Name))
-
-			if err := d.extractFile(reader, target, header.Mode); err != nil {
-				return err
+		if path.Clean(header.Name) != expected {
+			if doc, ok := d.docPath(destDir, expected, header.Name); ok && header.Typeflag == tar.TypeReg {
+				if err := d.extractDoc(reader, doc, header.Size); err != nil {
+					return fmt.Errorf("%s: %w", header.Name, err)
+				}
 			}
 
-			//nolint:gosec // Writing to stderr, not a web context - XSS not applicable
-			fmt.Fprintf(os.Stderr, "✓ Extracted binary to %s\n", target)
+			continue
+		}
 
-			return nil // Found and extracted the binary
+		if found {
+			return fmt.Errorf("%w: duplicate entry %s", ErrUnsafeEntry, header.Name)
 		}
+
+		if header.Typeflag != tar.TypeReg {
+			return fmt.Errorf("%w: %s is not a regular file", ErrUnsafeEntry, header.Name)
+		}
+
+		if err := d.extractFile(reader, target, header.Size, executablePermission); err != nil {
+			return fmt.Errorf("%s: %w", header.Name, err)
+		}
+
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("%w: no %s in archive", ErrBinaryNotFound, expected)
 	}
 
-	return ErrBinaryNotFound
+	//nolint:gosec // Writing to stderr, not a web context - XSS not applicable
+	fmt.Fprintf(os.Stderr, "✓ Extracted binary to %s\n", target)
+
+	return nil
 }
 
-func (d *Downloader) extractFile(reader io.Reader, target string, mode int64) error {
-	//nolint:gosec // Path is internally controlled
-	openFile, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR, os.FileMode(mode))
+// checkTarEntry refuses links and special files, which release archives never contain.
+func checkTarEntry(header *tar.Header) error {
+	switch header.Typeflag {
+	case tar.TypeSymlink, tar.TypeLink:
+		return fmt.Errorf("%w: %s links to %s", ErrUnsafeEntry, header.Name, header.Linkname)
+	case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+		return fmt.Errorf("%w: %s is a special file", ErrUnsafeEntry, header.Name)
+	default:
+		return nil
+	}
+}
+
+// docPath returns where to keep an archive entry shipped next to the binary, when docs are kept.
+func (d *Downloader) docPath(destDir, expected, name string) (string, bool) {
+	if !d.keepDocs {
+		return "", false
+	}
+
+	// Cleaning resolves .. elements, which then leave the binary directory
+	rel, ok := strings.CutPrefix(path.Clean(name), path.Dir(expected)+"/")
+	if !ok {
+		return "", false
+	}
+
+	return filepath.Join(destDir, config.DocsDir, filepath.FromSlash(rel)), true
+}
+
+// extractDoc writes a documentation file of an archive to target.
+func (d *Downloader) extractDoc(reader io.Reader, target string, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), directoryPermission); err != nil {
+		return fmt.Errorf("failed to create docs directory: %w", err)
+	}
+
+	return d.extractFile(reader, target, size, filePermission)
+}
+
+// extractFile writes the content of an archive entry to target, through a temporary file renamed into place.
+// size is the size announced by the archive, or -1 when unknown; the content must match it and stay under
+// maxExtractSize, to catch truncated archives and decompression bombs.
+func (d *Downloader) extractFile(reader io.Reader, target string, size int64, mode os.FileMode) error {
+	if size > maxExtractSize {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrEntryTooLarge, size, maxExtractSize)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+"-*.tmp")
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
 
-	// Limit extraction size to prevent decompression bombs
-	limitedReader := io.LimitReader(reader, maxExtractSize)
-	if _, err := io.Copy(openFile, limitedReader); err != nil {
-		_ = openFile.Close()
+	tmpPath := tmp.Name()
 
-		return fmt.Errorf("failed to extract file: %w", err)
+	written, err := io.Copy(tmp, io.LimitReader(reader, maxExtractSize+1))
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
 	}
 
-	_ = openFile.Close()
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		err = fmt.Errorf("%w: %w", ErrEntrySizeMismatch, err)
+	case err != nil:
+		err = fmt.Errorf("failed to extract file: %w", err)
+	case written > maxExtractSize:
+		err = fmt.Errorf("%w: limit is %d bytes", ErrEntryTooLarge, maxExtractSize)
+	case size >= 0 && written != size:
+		err = fmt.Errorf("%w: got %d bytes, expected %d", ErrEntrySizeMismatch, written, size)
+	}
 
-	// Make executable on Unix systems
-	if d.config.OS != "windows" {
-		//nolint:gosec // Path is validated using filepath.Base() to prevent traversal
-		if err := os.Chmod(target, executablePermission); err != nil {
-			return fmt.Errorf("failed to set executable permissions: %w", err)
+	// Make binaries executable on Unix systems
+	if err == nil && d.config.OS != "windows" {
+		if chmodErr := os.Chmod(tmpPath, mode); chmodErr != nil {
+			err = fmt.Errorf("failed to set permissions: %w", chmodErr)
 		}
 	}
 
-	return nil
+	if err == nil {
+		if renameErr := os.Rename(tmpPath, target); renameErr != nil {
+			err = fmt.Errorf("failed to move extracted file: %w", renameErr)
+		}
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+
+	return err
 }
@@ -0,0 +1,183 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDownload_MirrorFallback(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+
+	var brokenHits int
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		brokenHits++
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(broken.Close)
+
+	empty := newReleaseServer(t, map[string][]byte{})
+
+	good := newReleaseServer(t, map[string][]byte{
+		releaseAssetPath(testVersion):             archive,
+		releaseAssetPath(testVersion) + ".sha256": []byte(sha256Hex(archive) + "  archive.tar.gz\n"),
+	})
+
+	mirrors, err := ParseMirrors([]string{broken.URL, empty.URL, good.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors, Retry: fastRetry(2)})
+
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	// Retried once before falling back to the next mirror
+	if brokenHits != 2 {
+		t.Errorf("broken mirror hit %d times, want 2", brokenHits)
+	}
+
+	if !dl.cacheManager.IsCached(testVersion) {
+		t.Error("version should be cached after download")
+	}
+}
+
+func TestDownload_AllMirrorsFail(t *testing.T) {
+	empty := newReleaseServer(t, map[string][]byte{})
+
+	mirrors, err := ParseMirrors([]string{empty.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	err = dl.Download(context.Background(), testVersion)
+	if !errors.Is(err, ErrNoMirrors) {
+		t.Fatalf("Download() error = %v, want %v", err, ErrNoMirrors)
+	}
+
+	if dl.cacheManager.IsCached(testVersion) {
+		t.Error("version should not be cached after a failed download")
+	}
+}
+
+func TestDownload_NoFallbackOnClientError(t *testing.T) {
+	var secondHits int
+
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(forbidden.Close)
+
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		secondHits++
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(second.Close)
+
+	mirrors, err := ParseMirrors([]string{forbidden.URL, second.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	err = dl.Download(context.Background(), testVersion)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("Download() error = %v, want HTTP 403", err)
+	}
+
+	if secondHits != 0 {
+		t.Errorf("second mirror hit %d times, want 0", secondHits)
+	}
+}
+
+func TestDownload_ResumedArchiveIsVerified(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case releaseAssetPath(testVersion):
+			http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(archive))
+		case releaseAssetPath(testVersion) + ".sha256":
+			_, _ = w.Write([]byte(sha256Hex(archive)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	// Seed a corrupted partial download: the resumed archive must fail verification.
+	stagingDir := dl.config.GetStagingDir(testVersion)
+	if err := os.MkdirAll(stagingDir, directoryPermission); err != nil {
+		t.Fatalf("failed to create staging directory: %v", err)
+	}
+
+	corrupted := bytes.Repeat([]byte{0}, len(archive)/2)
+	if err := os.WriteFile(filepath.Join(stagingDir, "archive.tar.gz"+partialSuffix), corrupted, filePermission); err != nil {
+		t.Fatalf("failed to seed partial file: %v", err)
+	}
+
+	err = dl.Download(context.Background(), testVersion)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Download() error = %v, want %v", err, ErrChecksumMismatch)
+	}
+
+	for _, dir := range []string{stagingDir, dl.cacheManager.GetVersionDir(testVersion)} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed after a checksum mismatch", dir)
+		}
+	}
+}
+
+func TestDownload_KeepDocs(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+
+	server := newReleaseServer(t, map[string][]byte{
+		releaseAssetPath(testVersion):             archive,
+		releaseAssetPath(testVersion) + ".sha256": []byte(sha256Hex(archive)),
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	for _, keepDocs := range []bool{false, true} { //nolint:paralleltest // newTestDownloader uses t.Setenv
+		dl := newTestDownloader(t, Options{Mirrors: mirrors, KeepDocs: keepDocs})
+
+		if err := dl.Download(context.Background(), testVersion); err != nil {
+			t.Fatalf("Download() failed: %v", err)
+		}
+
+		readmePath := filepath.Join(dl.config.GetDocsDir(testVersion), "README.md")
+
+		_, err := os.Stat(readmePath)
+		if keepDocs {
+			assertFileContent(t, readmePath, []byte("readme"))
+		} else if !os.IsNotExist(err) {
+			t.Errorf("README.md should only be kept with KeepDocs, got %v", err)
+		}
+	}
+}
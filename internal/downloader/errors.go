@@ -1,21 +1,140 @@
 package downloader
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 // Downloader-related errors.
 var (
 	// ErrBinaryNotFound is returned when the golangci-lint binary is not found after extraction.
 	ErrBinaryNotFound = errors.New("golangci-lint binary not found after extraction")
 
+	// ErrUnsafeEntry is returned when an archive holds links, special files or duplicate binaries.
+	ErrUnsafeEntry = errors.New("unsafe archive entry")
+
+	// ErrEntryTooLarge is returned when an archive entry exceeds the extraction size limit.
+	ErrEntryTooLarge = errors.New("archive entry too large")
+
+	// ErrEntrySizeMismatch is returned when an archive entry is truncated or doesn't match its announced size.
+	ErrEntrySizeMismatch = errors.New("archive entry size mismatch")
+
+	// ErrBinaryMismatch is returned when an installed binary isn't an executable of the target platform.
+	ErrBinaryMismatch = errors.New("binary doesn't match the target platform")
+
+	// ErrSmokeTestFailed is returned when an installed binary fails to run.
+	ErrSmokeTestFailed = errors.New("binary smoke test failed")
+
+	// ErrVersionMismatch is returned when an installed binary reports another version than requested.
+	ErrVersionMismatch = errors.New("binary version mismatch")
+
 	// ErrChecksumMismatch is returned when downloaded file checksum doesn't match expected.
 	ErrChecksumMismatch = errors.New("checksum mismatch")
 
+	// ErrChecksumNotFound is returned when the checksums manifest has no entry for the archive.
+	ErrChecksumNotFound = errors.New("checksum not found in manifest")
+
+	// ErrChecksumUnavailable is returned when no checksum could be fetched for the archive.
+	ErrChecksumUnavailable = errors.New("checksum not available")
+
+	// ErrMalformedChecksum is returned when a checksum file is empty or doesn't contain a SHA-256.
+	ErrMalformedChecksum = errors.New("malformed checksum")
+
+	// ErrLockMissing is returned in frozen mode when the project has no lockfile.
+	ErrLockMissing = errors.New("lockfile missing")
+
+	// ErrLockStale is returned in frozen mode when the lockfile doesn't cover the requested version or platform.
+	ErrLockStale = errors.New("lockfile out of date")
+
+	// ErrNotRegularFile is returned when installing from a path that isn't a regular file.
+	ErrNotRegularFile = errors.New("not a regular file")
+
 	// ErrHTTPRequest is returned when an HTTP request fails.
 	ErrHTTPRequest = errors.New("HTTP request failed")
 
 	// ErrGitHubAPI is returned when GitHub API returns an error.
 	ErrGitHubAPI = errors.New("GitHub API error")
 
+	// ErrRateLimited is returned when the GitHub API rate limit is exhausted.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrInvalidVersionQuery is returned when a version constraint cannot be parsed.
+	ErrInvalidVersionQuery = errors.New("invalid version constraint")
+
+	// ErrNoMatchingVersion is returned when no release satisfies a version constraint.
+	ErrNoMatchingVersion = errors.New("no release matches")
+
 	// ErrInvalidKeepValue is returned when keep parameter is invalid.
 	ErrInvalidKeepValue = errors.New("keep must be >= 0")
+
+	// ErrInvalidMirror is returned when a mirror specification cannot be parsed.
+	ErrInvalidMirror = errors.New("invalid mirror")
+
+	// ErrNoMirrors is returned when no mirror could serve a request.
+	ErrNoMirrors = errors.New("no mirror available")
+
+	// ErrInvalidSource is returned when the release source configuration is invalid.
+	ErrInvalidSource = errors.New("invalid release source")
+
+	// ErrReleaseNotFound is returned when the release source doesn't list a version.
+	ErrReleaseNotFound = errors.New("release not found")
+
+	// ErrAssetNotFound is returned when a release has no archive for the requested platform.
+	ErrAssetNotFound = errors.New("no release asset")
+
+	// ErrRegistryAuth is returned when an OCI registry rejects the credentials or the token request.
+	ErrRegistryAuth = errors.New("registry authentication failed")
+
+	// ErrUnsupportedLayer is returned when an image layer uses an unsupported compression.
+	ErrUnsupportedLayer = errors.New("unsupported image layer")
+
+	// ErrGoNotFound is returned when building from source without a Go toolchain in PATH.
+	ErrGoNotFound = errors.New("go toolchain not found")
+
+	// ErrBuildFailed is returned when a go command fails while building golangci-lint from source.
+	ErrBuildFailed = errors.New("build from source failed")
+
+	// ErrInvalidCABundle is returned when the CA bundle doesn't contain any PEM certificate.
+	ErrInvalidCABundle = errors.New("invalid CA bundle")
+
+	// ErrInvalidClientCert is returned when the mTLS client certificate or key cannot be loaded.
+	ErrInvalidClientCert = errors.New("invalid client certificate")
 )
+
+// Transport-level failures, retried as transient errors.
+var (
+	// errRequestFailed wraps failures where no HTTP response was received.
+	errRequestFailed = errors.New("HTTP request failed")
+
+	// errTransferFailed wraps failures while reading a response body.
+	errTransferFailed = errors.New("transfer interrupted")
+)
+
+// StatusError is returned when a server answers with an unexpected HTTP status.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	// RetryAfter is the delay requested by the server through the Retry-After header.
+	RetryAfter time.Duration
+}
+
+// newStatusError creates a StatusError from a response.
+func newStatusError(url string, resp *http.Response) *StatusError {
+	return &StatusError{
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: HTTP %d: %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Unwrap makes StatusError match ErrHTTPRequest.
+func (e *StatusError) Unwrap() error {
+	return ErrHTTPRequest
+}
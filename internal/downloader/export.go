@@ -0,0 +1,74 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+// exportDirPermission is the permission of export destinations, typically in a project tree.
+const exportDirPermission os.FileMode = 0o750
+
+// Export installs a version for the downloader's platform if needed, then copies the verified
+// binary into destDir, e.g. to vendor it or add it to a container build context.
+// It returns the path of the exported binary.
+func (d *Downloader) Export(ctx context.Context, version, destDir string) (string, error) {
+	version = config.NormalizeVersion(version)
+
+	if err := d.Download(ctx, version); err != nil {
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+
+	if err := os.MkdirAll(destDir, exportDirPermission); err != nil {
+		return "", fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	target := filepath.Join(destDir, d.config.BinaryName())
+
+	if err := copyExecutable(d.cacheManager.GetBinaryPath(version), target); err != nil {
+		return "", err
+	}
+
+	return target, nil
+}
+
+// copyExecutable copies a binary through a temporary file renamed into place,
+// so an interrupted export never leaves a truncated binary behind.
+func copyExecutable(source, target string) error {
+	src, err := os.Open(source) //nolint:gosec // Path is internally controlled
+	if err != nil {
+		return fmt.Errorf("failed to open binary: %w", err)
+	}
+
+	defer func() { _ = src.Close() }()
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create binary: %w", err)
+	}
+
+	_, err = io.Copy(tmp, src)
+
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		//nolint:gosec // The exported binary must be executable
+		err = os.Chmod(tmp.Name(), executablePermission)
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), target)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to export binary: %w", err)
+	}
+
+	return nil
+}
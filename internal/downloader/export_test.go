@@ -0,0 +1,64 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+func TestExport_ForeignPlatform(t *testing.T) {
+	if runtime.GOOS == "windows" && runtime.GOARCH == "arm64" {
+		t.Skip("windows/arm64 is the host platform")
+	}
+
+	binary := fakeExecutable(t, "windows", "arm64")
+	archive := buildZip(t, testVersion, "arm64", binary)
+	assetPath := "/" + testVersion + "/golangci-lint-1.55.2-windows-arm64.zip"
+
+	server := newReleaseServer(t, map[string][]byte{
+		assetPath:             archive,
+		assetPath + ".sha256": []byte(sha256Hex(archive)),
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors, Platform: "windows/arm64"})
+
+	dest := filepath.Join(t.TempDir(), "bin")
+
+	target, err := dl.Export(context.Background(), testVersion, dest)
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+
+	if want := filepath.Join(dest, "golangci-lint.exe"); target != want {
+		t.Errorf("Export() = %s, want %s", target, want)
+	}
+
+	content, err := os.ReadFile(target)
+	if err != nil || !bytes.Equal(content, binary) {
+		t.Errorf("exported binary = %q (%v), want the archive binary", content, err)
+	}
+
+	cached := filepath.Join(dl.config.CacheDir, config.PlatformsDir, "windows-arm64", config.VersionsDir, testVersion)
+	if _, err := os.Stat(filepath.Join(cached, "golangci-lint.exe")); err != nil {
+		t.Errorf("foreign binary should be cached under %s: %v", cached, err)
+	}
+
+	host, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New() failed: %v", err)
+	}
+
+	if host.BinaryExists(testVersion) {
+		t.Error("foreign binary must not be installed as the host binary")
+	}
+}
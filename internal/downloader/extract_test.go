@@ -0,0 +1,244 @@
+package downloader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+const testBinaryPath = "golangci-lint-1.55.2-linux-amd64/golangci-lint"
+
+// writeTarGz writes a tar.gz archive of the given entries, cutting the tar stream to size when positive.
+func writeTarGz(t *testing.T, headers []*tar.Header, truncate int) string {
+	t.Helper()
+
+	var raw bytes.Buffer
+
+	tw := tar.NewWriter(&raw)
+
+	for _, header := range headers {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+
+		// Oversized entries only announce their size
+		if header.Typeflag == tar.TypeReg && header.Size <= maxExtractSize {
+			if _, err := tw.Write(bytes.Repeat([]byte("x"), int(header.Size))); err != nil {
+				t.Fatalf("failed to write tar content: %v", err)
+			}
+		}
+	}
+
+	_ = tw.Close()
+
+	content := raw.Bytes()
+	if truncate > 0 {
+		content = content[:truncate]
+	}
+
+	var buf bytes.Buffer
+
+	gzw := gzip.NewWriter(&buf)
+	_, _ = gzw.Write(content)
+	_ = gzw.Close()
+
+	archivePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := os.WriteFile(archivePath, buf.Bytes(), filePermission); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	return archivePath
+}
+
+func TestExtractTarGz(t *testing.T) {
+	t.Parallel()
+
+	binary := &tar.Header{Name: testBinaryPath, Mode: 0o755, Size: 64, Typeflag: tar.TypeReg}
+
+	tests := []struct {
+		name     string
+		headers  []*tar.Header
+		truncate int
+		wantErr  error
+	}{
+		{
+			name: "expected path",
+			headers: []*tar.Header{
+				{Name: "golangci-lint-1.55.2-linux-amd64/", Mode: 0o755, Typeflag: tar.TypeDir},
+				{Name: "golangci-lint-1.55.2-linux-amd64/README.md", Mode: 0o644, Size: 6, Typeflag: tar.TypeReg},
+				binary,
+			},
+		},
+		{
+			name:    "binary in another directory",
+			headers: []*tar.Header{{Name: "tools/golangci-lint", Mode: 0o755, Size: 64, Typeflag: tar.TypeReg}},
+			wantErr: ErrBinaryNotFound,
+		},
+		{
+			name:    "binary of another platform",
+			headers: []*tar.Header{{Name: "golangci-lint-1.55.2-linux-arm64/golangci-lint", Mode: 0o755, Size: 64, Typeflag: tar.TypeReg}},
+			wantErr: ErrBinaryNotFound,
+		},
+		{
+			name:    "symlink",
+			headers: []*tar.Header{{Name: testBinaryPath, Linkname: "/usr/bin/env", Typeflag: tar.TypeSymlink}},
+			wantErr: ErrUnsafeEntry,
+		},
+		{
+			name: "hardlink next to the binary",
+			headers: []*tar.Header{
+				binary,
+				{Name: "golangci-lint-1.55.2-linux-amd64/LICENSE", Linkname: "/etc/passwd", Typeflag: tar.TypeLink},
+			},
+			wantErr: ErrUnsafeEntry,
+		},
+		{
+			name:    "device",
+			headers: []*tar.Header{{Name: "golangci-lint-1.55.2-linux-amd64/null", Mode: 0o666, Typeflag: tar.TypeChar}},
+			wantErr: ErrUnsafeEntry,
+		},
+		{
+			name:    "duplicate binary",
+			headers: []*tar.Header{binary, binary},
+			wantErr: ErrUnsafeEntry,
+		},
+		{
+			name:    "larger than the limit",
+			headers: []*tar.Header{{Name: testBinaryPath, Mode: 0o755, Size: maxExtractSize + 1, Typeflag: tar.TypeReg}},
+			wantErr: ErrEntryTooLarge,
+		},
+		{
+			name:     "truncated",
+			headers:  []*tar.Header{binary},
+			truncate: 512 + 32,
+			wantErr:  ErrEntrySizeMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := writeTarGz(t, tt.headers, tt.truncate)
+			destDir := t.TempDir()
+
+			dl := &Downloader{config: &config.Config{OS: "linux", Arch: "amd64"}}
+
+			err := dl.extractTarGz(archivePath, destDir, testBinaryPath)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("extractTarGz() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil {
+				assertFileContent(t, filepath.Join(destDir, "golangci-lint"), bytes.Repeat([]byte("x"), 64))
+			}
+
+			if leftovers, _ := filepath.Glob(filepath.Join(destDir, ".*.tmp")); len(leftovers) > 0 {
+				t.Errorf("extractTarGz() left temporary files behind: %v", leftovers)
+			}
+		})
+	}
+}
+
+func TestExtractZip_Symlink(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	header := &zip.FileHeader{Name: "golangci-lint-1.55.2-windows-amd64/golangci-lint.exe"}
+	header.SetMode(os.ModeSymlink | 0o777)
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		t.Fatalf("failed to create zip entry: %v", err)
+	}
+
+	_, _ = w.Write([]byte("C:/Windows/System32/cmd.exe"))
+	_ = zw.Close()
+
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+	if err := os.WriteFile(archivePath, buf.Bytes(), filePermission); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	dl := &Downloader{config: &config.Config{OS: "windows", Arch: "amd64"}}
+
+	err = dl.extractZip(archivePath, t.TempDir(), archiveBinaryPath(testVersion, "windows-amd64", "golangci-lint.exe"))
+	if !errors.Is(err, ErrUnsafeEntry) {
+		t.Errorf("extractZip() error = %v, want %v", err, ErrUnsafeEntry)
+	}
+}
+
+func TestExtractFile_ReplacesExistingFile(t *testing.T) {
+	t.Parallel()
+
+	target := filepath.Join(t.TempDir(), "golangci-lint")
+
+	if err := os.WriteFile(target, []byte(strings.Repeat("stale binary ", 100)), filePermission); err != nil {
+		t.Fatalf("failed to write existing file: %v", err)
+	}
+
+	dl := &Downloader{config: &config.Config{OS: "linux"}}
+
+	if err := dl.extractFile(strings.NewReader("new binary"), target, int64(len("new binary")), executablePermission); err != nil {
+		t.Fatalf("extractFile() failed: %v", err)
+	}
+
+	assertFileContent(t, target, []byte("new binary"))
+
+	if err := dl.extractFile(strings.NewReader("short"), target, 64, executablePermission); !errors.Is(err, ErrEntrySizeMismatch) {
+		t.Errorf("extractFile() error = %v, want %v", err, ErrEntrySizeMismatch)
+	}
+
+	// A failed extraction leaves the previous file untouched
+	assertFileContent(t, target, []byte("new binary"))
+}
+
+func TestExtractTarGz_KeepDocs(t *testing.T) {
+	t.Parallel()
+
+	archivePath := writeTarGz(t, []*tar.Header{
+		{Name: "golangci-lint-1.55.2-linux-amd64/README.md", Mode: 0o644, Size: 6, Typeflag: tar.TypeReg},
+		{Name: "golangci-lint-1.55.2-linux-amd64/completions/golangci-lint.bash", Mode: 0o644, Size: 8, Typeflag: tar.TypeReg},
+		{Name: "golangci-lint-1.55.2-linux-amd64/../outside", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg},
+		{Name: "other/NOTICE", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg},
+		{Name: testBinaryPath, Mode: 0o755, Size: 64, Typeflag: tar.TypeReg},
+	}, 0)
+
+	destDir := filepath.Join(t.TempDir(), "extract")
+	if err := os.Mkdir(destDir, directoryPermission); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	dl := &Downloader{config: &config.Config{OS: "linux", Arch: "amd64"}, keepDocs: true}
+
+	if err := dl.extractTarGz(archivePath, destDir, testBinaryPath); err != nil {
+		t.Fatalf("extractTarGz() failed: %v", err)
+	}
+
+	var files []string
+
+	_ = filepath.WalkDir(filepath.Dir(destDir), func(path string, entry os.DirEntry, _ error) error {
+		if !entry.IsDir() {
+			rel, _ := filepath.Rel(destDir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+
+	want := []string{"docs/README.md", "docs/completions/golangci-lint.bash", "golangci-lint"}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("extracted files = %v, want %v", files, want)
+	}
+}
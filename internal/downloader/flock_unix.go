@@ -0,0 +1,29 @@
+//go:build unix
+
+package downloader
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile takes an exclusive, non-blocking lock on a file.
+// Returns false when another process holds the lock.
+func tryLockFile(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err //nolint:wrapcheck // Wrapped by the caller
+	}
+
+	return true, nil
+}
+
+// unlockFile releases a lock taken with tryLockFile.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN) //nolint:wrapcheck // Wrapped by the caller
+}
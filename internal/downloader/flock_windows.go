@@ -0,0 +1,36 @@
+//go:build windows
+
+package downloader
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// tryLockFile takes an exclusive, non-blocking lock on a file.
+// Returns false when another process holds the lock.
+func tryLockFile(file *os.File) (bool, error) {
+	overlapped := new(windows.Overlapped)
+
+	err := windows.LockFileEx(
+		windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0, overlapped,
+	)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err //nolint:wrapcheck // Wrapped by the caller
+	}
+
+	return true, nil
+}
+
+// unlockFile releases a lock taken with tryLockFile.
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, new(windows.Overlapped)) //nolint:wrapcheck // Wrapped by the caller
+}
@@ -6,66 +6,316 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Masterminds/semver/v3"
 )
 
 const (
-	clientTimeout = 30 * time.Second
-
-	githubAPIURL = "https://api.github.com/repos/golangci/golangci-lint/releases"
+	maxAPIErrorSize      = 64 * 1024
+	releasesPerPage      = 100
+	giteaReleasesPerPage = 50
+	maxReleasePages      = 50
 )
 
-// GitHubRelease represents a GitHub release.
-type GitHubRelease struct {
+// Release represents a golangci-lint release, as listed by GitHub-compatible releases APIs.
+type Release struct {
 	TagName     string    `json:"tag_name"`
 	Name        string    `json:"name"`
 	PublishedAt time.Time `json:"published_at"`
 	Prerelease  bool      `json:"prerelease"`
 	Draft       bool      `json:"draft"`
+	// Body holds the release notes, in Markdown.
+	Body string `json:"body,omitempty"`
+	// HTMLURL is the web page of the release.
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+// ReleaseFilter selects the releases returned by FetchAvailableVersions.
+type ReleaseFilter struct {
+	// Limit is the maximum number of releases returned, 0 for all of them.
+	Limit int
+	// Prerelease includes prereleases. Drafts are always excluded.
+	Prerelease bool
+	// Major keeps releases of a single major version, 0 for all of them.
+	Major uint64
+	// Since keeps releases published at or after this time, zero for all of them.
+	Since time.Time
+	// Match is an additional predicate releases must satisfy, nil for none.
+	Match func(Release) bool
+}
+
+// matches reports whether a release passes the filter.
+func (f ReleaseFilter) matches(release Release) bool {
+	if release.Draft || (release.Prerelease && !f.Prerelease) {
+		return false
+	}
+
+	if !f.Since.IsZero() && release.PublishedAt.Before(f.Since) {
+		return false
+	}
+
+	if f.Major != 0 {
+		version, err := semver.NewVersion(release.TagName)
+		if err != nil || version.Major() != f.Major {
+			return false
+		}
+	}
+
+	return f.Match == nil || f.Match(release)
+}
+
+// FetchAvailableVersions returns golangci-lint releases matching a filter, newest first.
+// Releases come from the cached release index, refreshed when it is older than its TTL.
+func (d *Downloader) FetchAvailableVersions(ctx context.Context, filter ReleaseFilter) ([]Release, error) {
+	releases, err := d.releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.apply(releases), nil
+}
+
+// apply returns the releases passing the filter, up to its limit.
+func (f ReleaseFilter) apply(releases []Release) []Release {
+	var matched []Release
+
+	for _, release := range releases {
+		if !f.matches(release) {
+			continue
+		}
+
+		matched = append(matched, release)
+
+		if f.Limit > 0 && len(matched) == f.Limit {
+			break
+		}
+	}
+
+	return matched
+}
+
+// apiSource is a release source backed by a GitHub-compatible releases API:
+// github.com, GitHub Enterprise, Gitea, or a mirror's api= endpoint.
+type apiSource struct {
+	// releasesURL is the releases API endpoint.
+	releasesURL string
+	// pageSize is the query parameter requesting the largest page the API allows.
+	pageSize string
+	// mirror is where release assets are downloaded from.
+	mirror Mirror
+	// authorization is the Authorization header sent to the API, empty for none.
+	authorization string
+}
+
+// newGitHubSource returns the source of a repository on github.com or GitHub Enterprise.
+func newGitHubSource(webURL, apiURL, repo, token string) *apiSource {
+	source := &apiSource{
+		releasesURL: apiURL + "/repos/" + repo + "/releases",
+		pageSize:    "per_page=" + strconv.Itoa(releasesPerPage),
+	}
+
+	source.mirror = Mirror{
+		BaseURL:          webURL + "/" + repo + "/releases/download",
+		APIURL:           source.releasesURL,
+		ArchiveTemplate:  defaultArchiveTemplate,
+		ChecksumTemplate: defaultChecksumTemplate,
+		ManifestTemplate: defaultManifestTemplate,
+	}
+
+	if token != "" {
+		source.authorization = "Bearer " + token
+	}
+
+	return source
+}
+
+// newGiteaSource returns the source of a repository on a Gitea (or Forgejo) instance.
+func newGiteaSource(baseURL, repo, token string) *apiSource {
+	source := &apiSource{
+		releasesURL: baseURL + "/api/v1/repos/" + repo + "/releases",
+		pageSize:    "limit=" + strconv.Itoa(giteaReleasesPerPage),
+	}
+
+	source.mirror = Mirror{
+		BaseURL:          baseURL + "/" + repo + "/releases/download",
+		APIURL:           source.releasesURL,
+		ArchiveTemplate:  defaultArchiveTemplate,
+		ChecksumTemplate: defaultChecksumTemplate,
+		ManifestTemplate: defaultManifestTemplate,
+	}
+
+	if token != "" {
+		source.authorization = "token " + token
+	}
+
+	return source
 }
 
-// FetchAvailableVersions fetches available golangci-lint versions from GitHub.
-func FetchAvailableVersions(ctx context.Context, limit int) ([]GitHubRelease, error) {
-	url := fmt.Sprintf("%s?per_page=%d", githubAPIURL, limit)
+// newMirrorSource returns the source listing releases through a mirror's api= endpoint.
+func newMirrorSource(mirror Mirror) *apiSource {
+	return &apiSource{
+		releasesURL: mirror.APIURL,
+		pageSize:    "per_page=" + strconv.Itoa(releasesPerPage),
+		mirror:      mirror,
+	}
+}
+
+// Name implements ReleaseSource.
+func (s *apiSource) Name() string {
+	return s.releasesURL
+}
+
+// assetMirror implements ReleaseSource.
+func (s *apiSource) assetMirror(_ context.Context, _ *Downloader, _, _, _ string) (Mirror, error) {
+	return s.mirror, nil
+}
+
+// fetchIndex implements ReleaseSource by following the pages of the releases API.
+// A cached index is revalidated with the ETag of the first page.
+func (s *apiSource) fetchIndex(ctx context.Context, d *Downloader, cached *releaseIndex) (*releaseIndex, error) {
+	etag := ""
+	if cached != nil && cached.Source == s.Name() {
+		etag = cached.ETag
+	}
+
+	index := &releaseIndex{Source: s.Name(), FetchedAt: time.Now()}
+	pageURL := s.releasesURL + "?" + s.pageSize
+
+	for pages := 0; pageURL != "" && pages < maxReleasePages; pages++ {
+		var page releasePage
+
+		err := d.retry.do(ctx, func() error {
+			var err error
+
+			page, err = s.fetchPage(ctx, d, pageURL, etag)
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// The first page didn't change, neither did the older releases
+		if page.notModified {
+			cached.FetchedAt = index.FetchedAt
+
+			return cached, nil
+		}
+
+		if pages == 0 {
+			index.ETag = page.etag
+			etag = ""
+		}
+
+		index.Releases = append(index.Releases, page.releases...)
+		pageURL = page.next
+	}
+
+	return index, nil
+}
+
+// releasePage is a page of a releases API.
+type releasePage struct {
+	releases    []Release
+	next        string
+	etag        string
+	notModified bool
+}
+
+// fetchPage fetches a page of the releases API, including drafts and prereleases.
+// When etag is set, the page is only downloaded if it changed.
+func (s *apiSource) fetchPage(ctx context.Context, d *Downloader, url, etag string) (releasePage, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return releasePage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	client := &http.Client{
-		Timeout: clientTimeout,
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
 
-	//nolint:gosec // URL is constructed from hardcoded GitHub API URL constant
-	resp, err := client.Do(req)
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	} else {
+		d.authorize(req)
+	}
+
+	//nolint:gosec // URL is constructed from configured sources
+	resp, err := d.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+		return releasePage{}, fmt.Errorf("failed to fetch releases: %w: %w", errRequestFailed, err)
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
+	if rateLimit := rateLimitError(resp); rateLimit != nil {
+		return releasePage{}, rateLimit
+	}
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		return releasePage{notModified: true}, nil
+	}
 
-		return nil, fmt.Errorf("%w: status %d: %s", ErrGitHubAPI, resp.StatusCode, string(body))
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError {
+		return releasePage{}, newStatusError(url, resp)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return releasePage{}, fmt.Errorf("%w: status %d: %s", ErrGitHubAPI, resp.StatusCode, apiErrorMessage(resp.Body))
 	}
 
-	var releases []GitHubRelease
+	var releases []Release
 	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return releasePage{}, fmt.Errorf("failed to decode response: %w: %w", errTransferFailed, err)
 	}
 
-	// Filter out drafts and prereleases
-	var stableReleases []GitHubRelease
+	return releasePage{
+		releases: releases,
+		next:     nextPageURL(resp.Header.Get("Link")),
+		etag:     resp.Header.Get("ETag"),
+	}, nil
+}
 
-	for _, release := range releases {
-		if !release.Draft && !release.Prerelease {
-			stableReleases = append(stableReleases, release)
+// nextPageURL returns the rel="next" URL of a Link header, or an empty string on the last page.
+//
+//	Link: <https://api.github.com/repositories/1/releases?page=2>; rel="next", <...>; rel="last"
+func nextPageURL(link string) string {
+	for part := range strings.SplitSeq(link, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if !ok {
+			continue
+		}
+
+		for param := range strings.SplitSeq(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
 		}
 	}
 
-	return stableReleases, nil
+	return ""
+}
+
+// apiErrorMessage extracts the message of a GitHub API error body, falling back to the raw body.
+func apiErrorMessage(body io.Reader) string {
+	content, _ := io.ReadAll(io.LimitReader(body, maxAPIErrorSize))
+
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(content, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+
+	return strings.TrimSpace(string(content))
 }
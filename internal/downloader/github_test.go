@@ -0,0 +1,192 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFetchAvailableVersions_MirrorAPI(t *testing.T) {
+	releases := []Release{
+		{TagName: "v2.1.0"},
+		{TagName: "v2.1.0-rc.1", Prerelease: true},
+		{TagName: "v2.0.0-draft", Draft: true},
+		{TagName: "v1.64.8"},
+	}
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(broken.Close)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(releases)
+	}))
+	t.Cleanup(api.Close)
+
+	mirrors := []Mirror{
+		{BaseURL: "https://no-api.example.com"},
+		{BaseURL: broken.URL, APIURL: broken.URL},
+		{BaseURL: api.URL, APIURL: api.URL},
+	}
+
+	dl := newTestDownloader(t, Options{
+		Mirrors: mirrors,
+		Retry:   RetryPolicy{Attempts: 2, BaseDelay: time.Millisecond},
+	})
+
+	got, err := dl.FetchAvailableVersions(context.Background(), ReleaseFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("FetchAvailableVersions() failed: %v", err)
+	}
+
+	if len(got) != 2 || got[0].TagName != "v2.1.0" || got[1].TagName != "v1.64.8" {
+		t.Errorf("FetchAvailableVersions() = %+v, want stable releases only", got)
+	}
+}
+
+// newPaginatedAPI serves releases in pages of pageSize, linked through the Link header.
+// It returns the server and a pointer to the number of pages served.
+func newPaginatedAPI(t *testing.T, releases []Release, pageSize int) (*httptest.Server, *int) {
+	t.Helper()
+
+	var pagesServed int
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pagesServed++
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+
+		start := min((page-1)*pageSize, len(releases))
+		end := min(start+pageSize, len(releases))
+
+		if end < len(releases) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/releases?page=%d>; rel="next", <%s/releases?page=99>; rel="last"`,
+				server.URL, page+1, server.URL))
+		}
+
+		_ = json.NewEncoder(w).Encode(releases[start:end])
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &pagesServed
+}
+
+func TestFetchAvailableVersions_Pagination(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 1, n, 0, 0, 0, 0, time.UTC) }
+
+	releases := []Release{
+		{TagName: "v2.1.0", PublishedAt: day(9)},
+		{TagName: "v2.1.0-rc.1", PublishedAt: day(8), Prerelease: true},
+		{TagName: "v2.0.0", PublishedAt: day(7)},
+		{TagName: "v2.0.0-draft", PublishedAt: day(6), Draft: true},
+		{TagName: "v1.64.8", PublishedAt: day(5)},
+		{TagName: "v1.64.7", PublishedAt: day(4)},
+		{TagName: "v1.64.6", PublishedAt: day(3)},
+	}
+
+	tests := []struct {
+		name   string
+		filter ReleaseFilter
+		want   []string
+	}{
+		{
+			name:   "limit applies after filtering",
+			filter: ReleaseFilter{Limit: 3},
+			want:   []string{"v2.1.0", "v2.0.0", "v1.64.8"},
+		},
+		{
+			name:   "all pages",
+			filter: ReleaseFilter{},
+			want:   []string{"v2.1.0", "v2.0.0", "v1.64.8", "v1.64.7", "v1.64.6"},
+		},
+		{
+			name:   "prereleases",
+			filter: ReleaseFilter{Limit: 2, Prerelease: true},
+			want:   []string{"v2.1.0", "v2.1.0-rc.1"},
+		},
+		{
+			name:   "major",
+			filter: ReleaseFilter{Major: 1, Limit: 2},
+			want:   []string{"v1.64.8", "v1.64.7"},
+		},
+		{
+			name:   "since",
+			filter: ReleaseFilter{Since: day(7)},
+			want:   []string{"v2.1.0", "v2.0.0"},
+		},
+		{
+			name:   "match",
+			filter: ReleaseFilter{Match: func(r Release) bool { return r.TagName == "v1.64.7" }},
+			want:   []string{"v1.64.7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, pagesServed := newPaginatedAPI(t, releases, 2)
+
+			dl := newTestDownloader(t, Options{Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}}})
+
+			got, err := dl.FetchAvailableVersions(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("FetchAvailableVersions() failed: %v", err)
+			}
+
+			tags := make([]string, 0, len(got))
+			for _, release := range got {
+				tags = append(tags, release.TagName)
+			}
+
+			if !slices.Equal(tags, tt.want) {
+				t.Errorf("FetchAvailableVersions() = %v, want %v", tags, tt.want)
+			}
+
+			// The whole release list is fetched once and cached
+			if *pagesServed != 4 {
+				t.Errorf("fetched %d pages, want 4", *pagesServed)
+			}
+		})
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{name: "no header", link: "", want: ""},
+		{
+			name: "next and last",
+			link: `<https://api.github.com/repositories/1/releases?page=2>; rel="next", <https://api.github.com/repositories/1/releases?page=5>; rel="last"`,
+			want: "https://api.github.com/repositories/1/releases?page=2",
+		},
+		{
+			name: "last page",
+			link: `<https://api.github.com/repositories/1/releases?page=4>; rel="prev", <https://api.github.com/repositories/1/releases?page=1>; rel="first"`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := nextPageURL(tt.link); got != tt.want {
+				t.Errorf("nextPageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+const (
+	// BuildFromSourceEnvVar is the environment variable enabling source builds for platforms without release archive.
+	BuildFromSourceEnvVar = "GLINT_VM_BUILD_FROM_SOURCE"
+
+	// defaultGoModule is the module of upstream golangci-lint, without the major version suffix.
+	defaultGoModule = "github.com/" + DefaultRepo
+	// goBuildModule is the module wrapping golangci-lint during a build.
+	goBuildModule = "glint-vm-build"
+	// maxGoMajor bounds the major versions probed when listing module versions.
+	maxGoMajor = 10
+)
+
+// goSource is a release source building golangci-lint from its Go module with the local Go
+// toolchain, for platforms without release archives. Versions are listed and downloaded
+// through the user's GOPROXY and GOFLAGS, so that a file:// proxy or a prepared module
+// cache can serve them offline.
+type goSource struct {
+	// module is the module path of golangci-lint v1, e.g. github.com/golangci/golangci-lint.
+	module string
+	// goTool is the go command.
+	goTool string
+}
+
+// goModuleVersions is the output of go list -m -versions -json.
+type goModuleVersions struct {
+	Path     string   `json:"Path"`
+	Versions []string `json:"Versions"`
+}
+
+// newGoSource returns the source building golangci-lint from a module.
+func newGoSource(module string) *goSource {
+	return &goSource{module: module, goTool: "go"}
+}
+
+// Name implements ReleaseSource.
+func (s *goSource) Name() string {
+	return "go:" + s.module
+}
+
+// modulePath returns the module path of a major version, which carries a /vN suffix from v2 on.
+func (s *goSource) modulePath(major uint64) string {
+	if major < 2 { //nolint:mnd // Major versions 0 and 1 have no suffix
+		return s.module
+	}
+
+	return fmt.Sprintf("%s/v%d", s.module, major)
+}
+
+// packagePath returns the main package of golangci-lint for a version.
+func (s *goSource) packagePath(version string) (string, error) {
+	parsed, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrInvalidVersionQuery, version, err)
+	}
+
+	return s.modulePath(parsed.Major()) + "/cmd/golangci-lint", nil
+}
+
+// assetMirror implements ReleaseSource. Builds have no release archive.
+func (s *goSource) assetMirror(_ context.Context, _ *Downloader, version, _, _ string) (Mirror, error) {
+	return Mirror{}, fmt.Errorf("%w: %s builds %s from source, without release archives", ErrAssetNotFound, s.Name(), version)
+}
+
+// fetchIndex implements ReleaseSource by listing the versions of each major version module,
+// up to the first one the proxy doesn't know.
+func (s *goSource) fetchIndex(ctx context.Context, _ *Downloader, _ *releaseIndex) (*releaseIndex, error) {
+	listDir, err := os.MkdirTemp("", "glint-vm-go-list-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(listDir) }()
+
+	var releases []Release
+
+	for major := uint64(1); major <= maxGoMajor; major++ {
+		output, err := s.run(ctx, listDir, s.env(nil), "list", "-m", "-versions", "-json", s.modulePath(major))
+		if err != nil {
+			if major == 1 {
+				return nil, err
+			}
+
+			break
+		}
+
+		var module goModuleVersions
+		if err := json.Unmarshal(output, &module); err != nil {
+			return nil, fmt.Errorf("failed to decode go list output: %w", err)
+		}
+
+		for _, version := range module.Versions {
+			parsed, err := semver.NewVersion(version)
+			if err != nil {
+				continue
+			}
+
+			releases = append(releases, Release{TagName: version, Name: version, Prerelease: parsed.Prerelease() != ""})
+		}
+	}
+
+	sortReleasesNewestFirst(releases)
+
+	return &releaseIndex{Source: s.Name(), FetchedAt: time.Now(), Releases: releases}, nil
+}
+
+// installBinary implements binarySource: it builds golangci-lint for the downloader's platform
+// into destDir, from a module wrapping it in stagingDir, and records the version as source-built.
+func (s *goSource) installBinary(ctx context.Context, d *Downloader, version, stagingDir, destDir string) error {
+	pkg, err := s.packagePath(version)
+	if err != nil {
+		return err
+	}
+
+	buildDir := filepath.Join(stagingDir, "build")
+
+	if err := os.RemoveAll(buildDir); err != nil {
+		return fmt.Errorf("failed to clean build directory: %w", err)
+	}
+
+	if err := os.Mkdir(buildDir, directoryPermission); err != nil {
+		return fmt.Errorf("failed to create build directory: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Building %s@%s for %s with the Go toolchain...\n", pkg, version, d.config.GetPlatformString())
+
+	env := s.env(d.config)
+
+	// The version is set as release builds do: built inside another module, golangci-lint
+	// would otherwise report the version of that module
+	ldflags := fmt.Sprintf("-s -w -X main.version=%s -X main.commit=unknown -X main.date=%s",
+		strings.TrimPrefix(version, "v"), time.Now().UTC().Format(time.RFC3339))
+
+	steps := [][]string{
+		{"mod", "init", goBuildModule},
+		{"get", pkg + "@" + version},
+		{"build", "-trimpath", "-ldflags", ldflags, "-o", filepath.Join(destDir, d.config.BinaryName()), pkg},
+	}
+
+	for _, args := range steps {
+		if _, err := s.run(ctx, buildDir, env, args...); err != nil {
+			return err
+		}
+	}
+
+	goVersion, err := s.run(ctx, buildDir, env, "env", "GOVERSION")
+	if err != nil {
+		return err
+	}
+
+	info := config.VersionInfo{SourceBuilt: true, GoVersion: strings.TrimSpace(string(goVersion))}
+
+	return config.WriteVersionInfo(destDir, info)
+}
+
+// env returns the environment of go commands: the user's one, outside any workspace,
+// cross-compiling for the platform of cfg when set. cgo is disabled, as in release builds.
+func (s *goSource) env(cfg *config.Config) []string {
+	env := append(os.Environ(), "GOWORK=off")
+
+	if cfg == nil {
+		return env
+	}
+
+	env = append(env, "GOOS="+cfg.OS, "GOARCH="+cfg.Arch, "CGO_ENABLED=0")
+
+	if cfg.ARM != "" {
+		env = append(env, "GOARM="+cfg.ARM)
+	}
+
+	return env
+}
+
+// run runs a go command in dir and returns its standard output.
+func (s *goSource) run(ctx context.Context, dir string, env []string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, s.goTool, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrGoNotFound, err)
+		}
+
+		return nil, fmt.Errorf("%w: go %s: %w: %s", ErrBuildFailed, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
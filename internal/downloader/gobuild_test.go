@@ -0,0 +1,264 @@
+package downloader
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+// fakeLinterSource is the main package of the modules served by the test Go proxy.
+// Like golangci-lint, it reports the version set with -ldflags.
+const fakeLinterSource = `package main
+
+import "fmt"
+
+var (
+	version = "(devel)"
+	date    = ""
+)
+
+func main() {
+	fmt.Printf("golangci-lint has version %s built with go from unknown on %s\n", version, date)
+}
+`
+
+// setupGoProxy serves module versions from a file:// GOPROXY, in an isolated module cache.
+// Tests are skipped when the go command isn't available.
+func setupGoProxy(t *testing.T, versions map[string][]string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	proxyDir := t.TempDir()
+
+	for module, moduleVersions := range versions {
+		versionsDir := filepath.Join(proxyDir, filepath.FromSlash(module), "@v")
+
+		if err := os.MkdirAll(versionsDir, directoryPermission); err != nil {
+			t.Fatalf("failed to create proxy directory: %v", err)
+		}
+
+		goMod := "module " + module + "\n\ngo 1.21\n"
+
+		for _, version := range moduleVersions {
+			files := map[string]string{
+				version + ".info": `{"Version":"` + version + `","Time":"2023-11-03T00:00:00Z"}`,
+				version + ".mod":  goMod,
+			}
+
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(versionsDir, name), []byte(content), filePermission); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			writeModuleZip(t, filepath.Join(versionsDir, version+".zip"), module+"@"+version, map[string]string{
+				"go.mod":                    goMod,
+				"cmd/golangci-lint/main.go": fakeLinterSource,
+			})
+		}
+
+		list := strings.Join(moduleVersions, "\n") + "\n"
+		if err := os.WriteFile(filepath.Join(versionsDir, "list"), []byte(list), filePermission); err != nil {
+			t.Fatalf("failed to write version list: %v", err)
+		}
+	}
+
+	t.Setenv("GOPROXY", "file://"+filepath.ToSlash(proxyDir))
+	t.Setenv("GOSUMDB", "off")
+	t.Setenv("GOTOOLCHAIN", "local")
+	t.Setenv("GOMODCACHE", t.TempDir())
+	// The module cache is read-only otherwise, and couldn't be cleaned up
+	t.Setenv("GOFLAGS", "-modcacherw")
+}
+
+// writeModuleZip writes a module zip, whose files are all under the module@version prefix.
+func writeModuleZip(t *testing.T, path, prefix string, files map[string]string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create module zip: %v", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	zw := zip.NewWriter(file)
+
+	for name, content := range files {
+		w, err := zw.Create(prefix + "/" + name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+
+		_, _ = w.Write([]byte(content))
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to write module zip: %v", err)
+	}
+}
+
+func TestGoSource_PackagePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantErr error
+	}{
+		{name: "v1", version: "v1.55.2", want: "github.com/golangci/golangci-lint/cmd/golangci-lint"},
+		{name: "v2", version: "v2.1.0", want: "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"},
+		{name: "invalid version", version: "latest", wantErr: ErrInvalidVersionQuery},
+	}
+
+	source := newGoSource(defaultGoModule)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := source.packagePath(tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("packagePath() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("packagePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoSource_FetchIndex(t *testing.T) {
+	setupGoProxy(t, map[string][]string{
+		defaultGoModule:         {"v1.55.2", "v1.56.0-rc.1"},
+		defaultGoModule + "/v2": {"v2.1.0"},
+	})
+
+	index, err := newGoSource(defaultGoModule).fetchIndex(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("fetchIndex() failed: %v", err)
+	}
+
+	want := []string{"v2.1.0", "v1.56.0-rc.1", "v1.55.2"}
+
+	var got []string
+
+	for _, release := range index.Releases {
+		got = append(got, release.TagName)
+
+		if release.Prerelease != strings.Contains(release.TagName, "-") {
+			t.Errorf("release %s: Prerelease = %v", release.TagName, release.Prerelease)
+		}
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fetchIndex() releases = %v, want %v", got, want)
+	}
+}
+
+func TestDownload_GoSource(t *testing.T) {
+	setupGoProxy(t, map[string][]string{defaultGoModule: {testVersion}})
+
+	dl := newTestDownloader(t, Options{Source: newGoSource(defaultGoModule)})
+
+	// The smoke test checks the version set at build time
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	info := dl.config.GetVersionInfo(testVersion)
+	if !info.SourceBuilt || !strings.HasPrefix(info.GoVersion, "go") {
+		t.Errorf("GetVersionInfo() = %+v, want a source build with its Go version", info)
+	}
+}
+
+func TestDownload_GoSourceStrict(t *testing.T) {
+	setupGoProxy(t, map[string][]string{defaultGoModule: {testVersion}})
+
+	dl := newTestDownloader(t, Options{Source: newGoSource(defaultGoModule), RequireChecksum: true})
+
+	if err := dl.Download(context.Background(), testVersion); !errors.Is(err, ErrChecksumUnavailable) {
+		t.Fatalf("Download() error = %v, want %v", err, ErrChecksumUnavailable)
+	}
+
+	if dl.cacheManager.IsCached(testVersion) {
+		t.Error("IsCached() = true, want false")
+	}
+}
+
+func TestDownload_BuildFromSourceFallback(t *testing.T) {
+	tests := []struct {
+		name            string
+		fallback        bool
+		locked          bool
+		frozen          bool
+		strict          bool
+		wantErr         error
+		wantSourceBuilt bool
+	}{
+		{name: "fallback enabled", fallback: true, wantSourceBuilt: true},
+		{name: "fallback disabled", wantErr: ErrAssetNotFound},
+		{name: "platform pinned by the lockfile", fallback: true, locked: true, wantSourceBuilt: true},
+		{name: "frozen", fallback: true, locked: true, frozen: true, wantErr: ErrChecksumUnavailable},
+		{name: "strict", fallback: true, strict: true, wantErr: ErrChecksumUnavailable},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // setupGoProxy and newTestDownloader use t.Setenv
+		t.Run(tt.name, func(t *testing.T) {
+			setupGoProxy(t, map[string][]string{defaultGoModule: {testVersion}})
+
+			// The release publishes an archive for another platform only
+			files := map[string][]byte{
+				manifestPath(testVersion): []byte(strings.Repeat("0", 64) + "  golangci-lint-1.55.2-plan9-amd64.tar.gz\n"),
+			}
+
+			mirrors, err := ParseMirrors([]string{newReleaseServer(t, files).URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{Mirrors: mirrors, BuildFromSource: tt.fallback, RequireChecksum: tt.strict})
+			dl.frozen = tt.frozen
+
+			if tt.locked {
+				platform := dl.config.GetPlatformString()
+				dl.lock = &lockfile.Lockfile{Version: testVersion, Checksums: map[string]string{platform: strings.Repeat("0", 64)}}
+			}
+
+			err = dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := dl.cacheManager.IsCached(testVersion); got != (tt.wantErr == nil) {
+				t.Errorf("IsCached() = %v, want %v", got, tt.wantErr == nil)
+			}
+
+			if info := dl.config.GetVersionInfo(testVersion); info.SourceBuilt != tt.wantSourceBuilt {
+				t.Errorf("GetVersionInfo().SourceBuilt = %v, want %v", info.SourceBuilt, tt.wantSourceBuilt)
+			}
+		})
+	}
+}
+
+func TestGoSource_MissingToolchain(t *testing.T) {
+	t.Parallel()
+
+	source := &goSource{module: defaultGoModule, goTool: "glint-vm-missing-go"}
+
+	if _, err := source.fetchIndex(context.Background(), nil, nil); !errors.Is(err, ErrGoNotFound) {
+		t.Errorf("fetchIndex() error = %v, want %v", err, ErrGoNotFound)
+	}
+}
@@ -0,0 +1,259 @@
+package downloader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testVersion = "v1.55.2"
+
+// fakeBinary is the content of the golangci-lint binary in fixture archives: the test binary
+// itself, which behaves as golangci-lint when run under that name (see TestMain).
+var fakeBinary []byte
+
+func TestMain(m *testing.M) {
+	if name := filepath.Base(os.Args[0]); name == "golangci-lint" || name == "golangci-lint.exe" {
+		runFakeLinter()
+
+		return
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to locate test binary: %v\n", err)
+		os.Exit(1)
+	}
+
+	fakeBinary, err = os.ReadFile(executable) //nolint:gosec // Path of the running test binary
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read test binary: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+// runFakeLinter answers golangci-lint --version with the version of its closest parent directory
+// named after one, as verified in staging/<version>/extract/ and installed in versions/<version>/.
+func runFakeLinter() {
+	version := strings.TrimPrefix(filepath.Base(versionDir(os.Args[0])), "v")
+
+	fmt.Printf("golangci-lint has version %s built with %s from 0000000 on 2023-11-03T00:00:00Z\n", version, runtime.Version())
+}
+
+// versionDir returns the closest parent directory of path named after a version, such as v1.55.2.
+func versionDir(path string) string {
+	dir := filepath.Dir(path)
+
+	for {
+		base := filepath.Base(dir)
+		if len(base) > 1 && base[0] == 'v' && base[1] >= '0' && base[1] <= '9' {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+
+		dir = parent
+	}
+}
+
+// fakeExecutable returns the bare executable header of a binary for another platform,
+// which passes the format check but cannot run.
+func fakeExecutable(t *testing.T, goos, goarch string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	var header any
+
+	switch goos {
+	case "windows":
+		machine, _ := peMachine(goarch)
+		dos := make([]byte, 64)
+		copy(dos, "MZ")
+		binary.LittleEndian.PutUint32(dos[0x3c:], uint32(len(dos)))
+
+		buf.Write(dos)
+		buf.WriteString("PE\x00\x00")
+
+		header = pe.FileHeader{Machine: machine}
+	case "darwin":
+		cpu, _ := machoCPU(goarch)
+
+		header = struct {
+			macho.FileHeader
+			Reserved uint32
+		}{FileHeader: macho.FileHeader{Magic: macho.Magic64, Cpu: cpu, Type: macho.TypeExec}}
+	default:
+		machine, _ := elfMachine(goarch)
+
+		header = elf.Header64{
+			Ident:     [elf.EI_NIDENT]byte{0x7f, 'E', 'L', 'F', byte(elf.ELFCLASS64), byte(elf.ELFDATA2LSB), byte(elf.EV_CURRENT)},
+			Type:      uint16(elf.ET_EXEC),
+			Machine:   uint16(machine),
+			Version:   uint32(elf.EV_CURRENT),
+			Ehsize:    64,
+			Phentsize: 56,
+			Shentsize: 64,
+		}
+	}
+
+	if err := binary.Write(&buf, binary.LittleEndian, header); err != nil {
+		t.Fatalf("failed to write executable header: %v", err)
+	}
+
+	// Padding for readers expecting more than the header
+	buf.Write(make([]byte, 512))
+
+	return buf.Bytes()
+}
+
+// buildTarball returns a release-like tar.gz archive for the host platform containing the given binary.
+func buildTarball(t *testing.T, version string, binary []byte) []byte {
+	t.Helper()
+
+	return buildTarballFor(t, version, runtime.GOOS+"-"+runtime.GOARCH, binary)
+}
+
+// buildTarballFor returns a release-like tar.gz archive for a platform (os-arch) containing the given binary.
+func buildTarballFor(t *testing.T, version, platform string, binary []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gzw, _ := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	tw := tar.NewWriter(gzw)
+
+	dir := "golangci-lint-" + strings.TrimPrefix(version, "v") + "-" + platform
+
+	files := map[string][]byte{
+		dir + "/README.md":     []byte("readme"),
+		dir + "/golangci-lint": binary,
+	}
+
+	for _, name := range []string{dir + "/README.md", dir + "/golangci-lint"} {
+		header := &tar.Header{
+			Name:     name,
+			Mode:     0o755,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+
+		if _, err := tw.Write(files[name]); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// buildZip returns a release-like Windows zip archive containing the given binary.
+func buildZip(t *testing.T, version, goarch string, binary []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+	dir := "golangci-lint-" + strings.TrimPrefix(version, "v") + "-windows-" + goarch
+
+	for name, content := range map[string][]byte{
+		dir + "/README.md":         []byte("readme"),
+		dir + "/golangci-lint.exe": binary,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+
+		if _, err := w.Write(content); err != nil {
+			t.Fatalf("failed to write zip entry: %v", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// newReleaseServer serves the given files (path -> content) and answers 404 otherwise.
+func newReleaseServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(content)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// releaseAssetPath returns the default mirror path of the archive for the host platform.
+func releaseAssetPath(version string) string {
+	return "/" + version + "/golangci-lint-" + strings.TrimPrefix(version, "v") + "-" + runtime.GOOS + "-" + runtime.GOARCH + ".tar.gz"
+}
+
+// manifestPath returns the default mirror path of the checksums manifest.
+func manifestPath(version string) string {
+	return "/" + version + "/golangci-lint-" + strings.TrimPrefix(version, "v") + "-checksums.txt"
+}
+
+// newTestDownloader creates a downloader using a temporary cache directory.
+func newTestDownloader(t *testing.T, opts Options) *Downloader {
+	t.Helper()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	dl, err := NewDownloader(opts)
+	if err != nil {
+		t.Fatalf("NewDownloader() failed: %v", err)
+	}
+
+	return dl
+}
@@ -0,0 +1,198 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+const (
+	// releaseIndexTTL is how long the cached release index is used without revalidation.
+	releaseIndexTTL = time.Hour
+	// releaseIndexFormat is bumped when cached indexes lack fields of newer releases and must be fetched again.
+	releaseIndexFormat = 2
+
+	// LatestVersion resolves to the newest stable release.
+	LatestVersion = "latest"
+)
+
+// releaseIndex is the list of upstream releases cached on disk.
+type releaseIndex struct {
+	// Format is the releaseIndexFormat the index was written with.
+	Format int `json:"format"`
+	// Source is the releases API the index was fetched from.
+	Source string `json:"source"`
+	// ETag of the first page, used to revalidate the index.
+	ETag string `json:"etag,omitempty"`
+	// FetchedAt is when the index was last fetched or revalidated.
+	FetchedAt time.Time `json:"fetched_at"`
+	Releases  []Release `json:"releases"`
+}
+
+// releases returns all upstream releases, newest first. The cached index is used while fresh,
+// revalidated once stale, and served as is with a warning when the releases API is unreachable.
+func (d *Downloader) releases(ctx context.Context) ([]Release, error) {
+	cached := d.loadReleaseIndex()
+	if cached != nil && !slices.ContainsFunc(d.releaseSources(), func(source ReleaseSource) bool {
+		return source.Name() == cached.Source
+	}) {
+		// Fetched from a source that is no longer configured
+		cached = nil
+	}
+
+	if cached != nil && time.Since(cached.FetchedAt) < releaseIndexTTL {
+		return cached.Releases, nil
+	}
+
+	var fetchedAt time.Time
+	if cached != nil {
+		fetchedAt = cached.FetchedAt
+	}
+
+	index, err := d.fetchReleaseIndex(ctx, cached)
+	if err != nil {
+		if cached == nil || ctx.Err() != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: failed to refresh the release list, using the one fetched %s ago: %v\n",
+			time.Since(fetchedAt).Round(time.Minute), err)
+
+		return cached.Releases, nil
+	}
+
+	if err := d.saveReleaseIndex(index); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache the release list: %v\n", err)
+	}
+
+	return index.Releases, nil
+}
+
+// CachedVersions returns the stable release tags of the cached index without any network access,
+// e.g. for shell completion. It returns nothing when no index was fetched yet.
+func (d *Downloader) CachedVersions() []string {
+	index := d.loadReleaseIndex()
+	if index == nil {
+		return nil
+	}
+
+	releases := ReleaseFilter{}.apply(index.Releases)
+
+	versions := make([]string, 0, len(releases))
+	for _, release := range releases {
+		versions = append(versions, release.TagName)
+	}
+
+	return versions
+}
+
+// ResolveVersion resolves "latest" or a semver constraint (^1.55, ~1.64.0, >=1.60 <2, 1.x)
+// to the newest matching stable release. Other versions are only normalized.
+func (d *Downloader) ResolveVersion(ctx context.Context, spec string) (string, error) {
+	if !isVersionQuery(spec) {
+		return config.NormalizeVersion(spec), nil
+	}
+
+	constraint, err := semver.NewConstraint("*")
+	if spec != LatestVersion {
+		constraint, err = semver.NewConstraint(spec)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrInvalidVersionQuery, spec, err)
+	}
+
+	releases, err := d.releases(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	var newest *semver.Version
+
+	stable := ReleaseFilter{}.apply(releases)
+
+	for _, release := range stable {
+		version, err := semver.NewVersion(release.TagName)
+		if err != nil || !constraint.Check(version) {
+			continue
+		}
+
+		if newest == nil || version.GreaterThan(newest) {
+			newest = version
+		}
+	}
+
+	if newest == nil {
+		return "", fmt.Errorf("%w: %s", ErrNoMatchingVersion, spec)
+	}
+
+	return newest.Original(), nil
+}
+
+// isVersionQuery reports whether a version argument must be resolved against the release list.
+func isVersionQuery(spec string) bool {
+	return spec == LatestVersion || strings.ContainsAny(spec, "^~<>=*xX|, ")
+}
+
+// loadReleaseIndex reads the cached release index, or returns nil when it is missing, unreadable
+// or written in an older format.
+func (d *Downloader) loadReleaseIndex() *releaseIndex {
+	content, err := os.ReadFile(d.config.GetReleaseIndexPath())
+	if err != nil {
+		return nil
+	}
+
+	var index releaseIndex
+	if err := json.Unmarshal(content, &index); err != nil || index.Format != releaseIndexFormat {
+		return nil
+	}
+
+	return &index
+}
+
+// saveReleaseIndex atomically writes the release index to the cache directory.
+func (d *Downloader) saveReleaseIndex(index *releaseIndex) error {
+	index.Format = releaseIndexFormat
+
+	content, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode release index: %w", err)
+	}
+
+	path := d.config.GetReleaseIndexPath()
+
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermission); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create release index: %w", err)
+	}
+
+	_, err = tmp.Write(content)
+
+	err = errors.Join(err, tmp.Close())
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write release index: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write release index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newIndexAPI serves releases with an ETag, answering 304 to matching If-None-Match requests.
+// It returns the server and pointers to the number of requests and 304 answers.
+func newIndexAPI(t *testing.T, releases []Release) (*httptest.Server, *int, *int) {
+	t.Helper()
+
+	var requests, notModified int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("ETag", `"v1"`)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(releases)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests, &notModified
+}
+
+// ageReleaseIndex makes the cached release index look fetched some time ago.
+func ageReleaseIndex(t *testing.T, dl *Downloader, age time.Duration) {
+	t.Helper()
+
+	index := dl.loadReleaseIndex()
+	if index == nil {
+		t.Fatal("release index should be cached")
+	}
+
+	index.FetchedAt = time.Now().Add(-age)
+
+	if err := dl.saveReleaseIndex(index); err != nil {
+		t.Fatalf("saveReleaseIndex() failed: %v", err)
+	}
+}
+
+func TestReleases_CachedIndex(t *testing.T) {
+	api, requests, notModified := newIndexAPI(t, []Release{{TagName: "v2.1.0"}, {TagName: "v1.64.8"}})
+
+	dl := newTestDownloader(t, Options{Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}}})
+
+	for range 2 {
+		if _, err := dl.releases(context.Background()); err != nil {
+			t.Fatalf("releases() failed: %v", err)
+		}
+	}
+
+	if *requests != 1 {
+		t.Errorf("API hit %d times, a fresh index should be reused", *requests)
+	}
+
+	ageReleaseIndex(t, dl, 2*releaseIndexTTL)
+
+	releases, err := dl.releases(context.Background())
+	if err != nil {
+		t.Fatalf("releases() failed: %v", err)
+	}
+
+	if *notModified != 1 || len(releases) != 2 {
+		t.Errorf("stale index should be revalidated with its ETag, got %d revalidations and %d releases", *notModified, len(releases))
+	}
+
+	if index := dl.loadReleaseIndex(); time.Since(index.FetchedAt) > time.Minute {
+		t.Errorf("revalidated index fetch time = %s, want now", index.FetchedAt)
+	}
+}
+
+func TestReleases_OfflineFallback(t *testing.T) {
+	api, _, _ := newIndexAPI(t, []Release{{TagName: "v2.1.0"}})
+
+	dl := newTestDownloader(t, Options{
+		Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}},
+		Retry:   fastRetry(1),
+	})
+
+	if _, err := dl.releases(context.Background()); err != nil {
+		t.Fatalf("releases() failed: %v", err)
+	}
+
+	ageReleaseIndex(t, dl, 2*releaseIndexTTL)
+	api.Close()
+
+	releases, err := dl.releases(context.Background())
+	if err != nil {
+		t.Fatalf("releases() should serve the stale index when offline, got: %v", err)
+	}
+
+	if len(releases) != 1 || releases[0].TagName != "v2.1.0" {
+		t.Errorf("releases() = %+v, want the cached release", releases)
+	}
+
+	if err := os.Remove(dl.config.GetReleaseIndexPath()); err != nil {
+		t.Fatalf("failed to remove release index: %v", err)
+	}
+
+	if _, err := dl.releases(context.Background()); err == nil {
+		t.Error("releases() should fail when offline without a cached index")
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	api, _, _ := newIndexAPI(t, []Release{
+		{TagName: "v2.2.0-rc.1", Prerelease: true},
+		{TagName: "v1.64.8"},
+		{TagName: "v2.1.6"},
+		{TagName: "v2.1.5"},
+		{TagName: "v1.63.4"},
+	})
+
+	dl := newTestDownloader(t, Options{Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}}})
+
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr error
+	}{
+		{spec: "latest", want: "v2.1.6"},
+		{spec: "^1.63", want: "v1.64.8"},
+		{spec: "~1.63.0", want: "v1.63.4"},
+		{spec: ">=2.0, <2.1.6", want: "v2.1.5"},
+		{spec: "2.x", want: "v2.1.6"},
+		{spec: "1.55.2", want: "v1.55.2"},
+		{spec: "v2.0.0", want: "v2.0.0"},
+		{spec: "^3", wantErr: ErrNoMatchingVersion},
+		{spec: ">=banana", wantErr: ErrInvalidVersionQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := dl.ResolveVersion(context.Background(), tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveVersion(%q) error = %v, want %v", tt.spec, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ResolveVersion(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachedVersions(t *testing.T) {
+	api, requests, _ := newIndexAPI(t, []Release{{TagName: "v2.1.0"}, {TagName: "v2.1.0-rc.1", Prerelease: true}})
+
+	dl := newTestDownloader(t, Options{Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}}})
+
+	if got := dl.CachedVersions(); got != nil {
+		t.Errorf("CachedVersions() = %v, want nothing before the index is fetched", got)
+	}
+
+	if _, err := dl.releases(context.Background()); err != nil {
+		t.Fatalf("releases() failed: %v", err)
+	}
+
+	ageReleaseIndex(t, dl, 2*releaseIndexTTL)
+
+	if got := dl.CachedVersions(); !slices.Equal(got, []string{"v2.1.0"}) {
+		t.Errorf("CachedVersions() = %v, want [v2.1.0]", got)
+	}
+
+	if *requests != 1 {
+		t.Errorf("CachedVersions() should not hit the network, API hit %d times", *requests)
+	}
+}
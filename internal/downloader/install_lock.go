@@ -0,0 +1,145 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	lockPollInterval = 100 * time.Millisecond
+	// stagingMaxAge is how long an abandoned staging directory is kept before being swept.
+	stagingMaxAge = 24 * time.Hour
+)
+
+// acquireInstallLock takes the cross-process lock for a version, waiting for
+// other installs of the same version to finish. The returned function releases it.
+func (d *Downloader) acquireInstallLock(ctx context.Context, version string) (func(), error) {
+	lockPath := d.config.GetLockPath(version)
+
+	unlock, acquired, err := tryInstallLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if acquired {
+		return unlock, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Waiting for another glint-vm process installing %s...\n", version)
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for install lock: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		unlock, acquired, err = tryInstallLock(lockPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if acquired {
+			return unlock, nil
+		}
+	}
+}
+
+// tryInstallLock tries to lock a file without blocking.
+func tryInstallLock(lockPath string) (func(), bool, error) {
+	if err := os.MkdirAll(filepath.Dir(lockPath), directoryPermission); err != nil {
+		return nil, false, fmt.Errorf("failed to create locks directory: %w", err)
+	}
+
+	//nolint:gosec // Path is internally controlled
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, filePermission)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	acquired, err := tryLockFile(file)
+	if err != nil || !acquired {
+		_ = file.Close()
+
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to lock %s: %w", lockPath, err)
+		}
+
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+	}
+
+	return unlock, true, nil
+}
+
+// prepareStagingDir creates the staging directory of a version. Leftovers from an
+// interrupted install are removed, except a partial download that can be resumed.
+// Must be called with the install lock held.
+func (d *Downloader) prepareStagingDir(version string) (string, error) {
+	stagingDir := d.config.GetStagingDir(version)
+
+	if err := os.MkdirAll(stagingDir, directoryPermission); err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read staging directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if isResumableFile(entry.Name()) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(stagingDir, entry.Name())); err != nil {
+			return "", fmt.Errorf("failed to clean staging directory: %w", err)
+		}
+	}
+
+	return stagingDir, nil
+}
+
+// isResumableFile reports whether a staging file is part of a resumable download.
+func isResumableFile(name string) bool {
+	ext := filepath.Ext(name)
+
+	return ext == partialSuffix || ext == validatorSuffix
+}
+
+// sweepStaging removes staging directories abandoned by killed processes.
+// Directories whose install lock is held by a running process are left alone.
+func (d *Downloader) sweepStaging() {
+	stagingRoot := d.config.GetStagingRoot()
+
+	entries, err := os.ReadDir(stagingRoot)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < stagingMaxAge {
+			continue
+		}
+
+		unlock, acquired, err := tryInstallLock(d.config.GetLockPath(entry.Name()))
+		if err != nil || !acquired {
+			continue
+		}
+
+		_ = os.RemoveAll(filepath.Join(stagingRoot, entry.Name()))
+
+		unlock()
+	}
+}
@@ -0,0 +1,142 @@
+package downloader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownload_ConcurrentInstallsDownloadOnce(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+
+	var archiveHits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case releaseAssetPath(testVersion):
+			archiveHits.Add(1)
+			time.Sleep(50 * time.Millisecond) // Keep the lock held while others wait
+
+			_, _ = w.Write(archive)
+		case releaseAssetPath(testVersion) + ".sha256":
+			_, _ = w.Write([]byte(sha256Hex(archive)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	// Each downloader has its own lock file descriptor, like separate processes.
+	newTestDownloader(t, Options{Mirrors: mirrors})
+
+	const installers = 4
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, installers)
+
+	for range installers {
+		dl, err := NewDownloader(Options{Mirrors: mirrors})
+		if err != nil {
+			t.Fatalf("NewDownloader() failed: %v", err)
+		}
+
+		wg.Go(func() {
+			errs <- dl.Download(context.Background(), testVersion)
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Download() failed: %v", err)
+		}
+	}
+
+	if hits := archiveHits.Load(); hits != 1 {
+		t.Errorf("archive downloaded %d times, want 1", hits)
+	}
+}
+
+func TestDownload_CancelRemovesStaging(t *testing.T) {
+	started := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000000")
+		_, _ = w.Write([]byte("partial content"))
+		w.(http.Flusher).Flush()
+
+		close(started)
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	if err := dl.Download(ctx, testVersion); err == nil {
+		t.Fatal("Download() should fail when cancelled")
+	}
+
+	for _, dir := range []string{dl.config.GetStagingDir(testVersion), dl.cacheManager.GetVersionDir(testVersion)} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed after cancellation", dir)
+		}
+	}
+}
+
+func TestSweepStaging(t *testing.T) {
+	dl := newTestDownloader(t, Options{})
+
+	staleDir := dl.config.GetStagingDir("v1.0.0")
+	freshDir := dl.config.GetStagingDir("v1.1.0")
+
+	for _, dir := range []string{staleDir, freshDir} {
+		if err := os.MkdirAll(dir, directoryPermission); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "archive.tar.gz"+partialSuffix), []byte("data"), filePermission); err != nil {
+			t.Fatalf("failed to seed partial file: %v", err)
+		}
+	}
+
+	old := time.Now().Add(-2 * stagingMaxAge)
+	if err := os.Chtimes(staleDir, old, old); err != nil {
+		t.Fatalf("failed to age staging directory: %v", err)
+	}
+
+	dl.sweepStaging()
+
+	if _, err := os.Stat(staleDir); !os.IsNotExist(err) {
+		t.Error("stale staging directory should be swept")
+	}
+
+	if _, err := os.Stat(freshDir); err != nil {
+		t.Errorf("fresh staging directory should be kept: %v", err)
+	}
+}
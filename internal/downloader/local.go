@@ -0,0 +1,161 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+// InstallFromFile installs a version from a local release archive (.tar.gz, .tgz, .zip)
+// or a bare golangci-lint binary, for hosts without network access.
+// The file is verified against checksumFile, which can be a .sha256 file or a checksums manifest.
+func (d *Downloader) InstallFromFile(ctx context.Context, filePath, version, checksumFile string) error {
+	version = config.NormalizeVersion(version)
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%w: %s", ErrNotRegularFile, filePath)
+	}
+
+	isArchive := isArchiveFile(filePath)
+
+	// The lockfile pins release archives, not bare binaries. Local archives are native ones.
+	lockedChecksum := ""
+	if isArchive {
+		lockedChecksum, err = d.lockedChecksumFor(version, d.config.GetPlatformString())
+		if err != nil {
+			return err
+		}
+	} else if checksumFile == "" && (d.frozen || d.requireChecksum) {
+		return fmt.Errorf("%w: %s pins release archives, give a checksum file for %s",
+			ErrChecksumUnavailable, lockfile.FileName, filepath.Base(filePath))
+	}
+
+	if err := d.verifyLocalChecksum(filePath, checksumFile, lockedChecksum); err != nil {
+		return fmt.Errorf("checksum verification failed: %w", err)
+	}
+
+	unlock, err := d.acquireInstallLock(ctx, version)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	stagingDir, err := d.prepareStagingDir(version)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.RemoveAll(stagingDir) }()
+
+	extractDir := filepath.Join(stagingDir, "extract")
+
+	if err := os.Mkdir(extractDir, directoryPermission); err != nil {
+		return fmt.Errorf("failed to create extraction directory: %w", err)
+	}
+
+	if isArchive {
+		err = d.extractArchive(filePath, extractDir, version, d.config.AssetArch())
+	} else {
+		err = d.copyBinary(filePath, extractDir)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to install %s: %w", filePath, err)
+	}
+
+	if err := d.commitVersion(ctx, version, d.config.AssetArch(), extractDir); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Successfully installed golangci-lint %s from %s\n", version, filePath)
+	fmt.Fprintf(os.Stderr, "  Location: %s\n", d.cacheManager.GetBinaryPath(version))
+
+	return nil
+}
+
+// verifyLocalChecksum verifies a local file against the lockfile or a checksum file.
+func (d *Downloader) verifyLocalChecksum(filePath, checksumFile, lockedChecksum string) error {
+	if lockedChecksum != "" {
+		return verifyFileChecksum(filePath, lockedChecksum, lockfile.FileName)
+	}
+
+	if checksumFile == "" {
+		if d.requireChecksum {
+			return fmt.Errorf("%w: no checksum file given", ErrChecksumUnavailable)
+		}
+
+		fmt.Fprintln(os.Stderr, "Warning: No checksum file given, skipping verification")
+
+		return nil
+	}
+
+	content, err := os.ReadFile(checksumFile) //nolint:gosec // Path is provided by the user on purpose
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrChecksumUnavailable, err)
+	}
+
+	source := filepath.Base(checksumFile)
+
+	// A manifest lists the file by name; a bare .sha256 file holds a single hash and nothing else
+	hash, ok := findManifestChecksum(content, filepath.Base(filePath))
+	if !ok {
+		fields := strings.Fields(string(content))
+
+		switch len(fields) {
+		case 0:
+			return fmt.Errorf("%w: %s is empty", ErrMalformedChecksum, source)
+		case 1:
+			hash = fields[0]
+		default:
+			return fmt.Errorf("%w: %s doesn't list %s", ErrChecksumNotFound, source, filepath.Base(filePath))
+		}
+	}
+
+	hash, _, err = validateChecksum(hash, source)
+	if err != nil {
+		return err
+	}
+
+	return verifyFileChecksum(filePath, hash, source)
+}
+
+// copyBinary copies a bare golangci-lint binary into destination directory.
+func (d *Downloader) copyBinary(filePath, destDir string) error {
+	source, err := os.Open(filePath) //nolint:gosec // Path is provided by the user on purpose
+	if err != nil {
+		return fmt.Errorf("failed to open binary: %w", err)
+	}
+
+	defer func() { _ = source.Close() }()
+
+	info, err := source.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read binary: %w", err)
+	}
+
+	target := filepath.Join(destDir, d.config.BinaryName())
+
+	return d.extractFile(source, target, info.Size(), executablePermission)
+}
+
+// isArchiveFile reports whether a file is a release archive, based on its extension.
+func isArchiveFile(filePath string) bool {
+	for _, ext := range []string{"." + tarGzExtension, ".tgz", "." + zipExtension} {
+		if strings.HasSuffix(filePath, ext) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestInstallFromFile(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+	archiveName := assetName(testVersion, runtime.GOOS, runtime.GOARCH)
+
+	tests := []struct {
+		name         string
+		fileName     string
+		content      []byte
+		checksum     string // content of the checksum file, "" for none
+		strict       bool
+		frozen       bool
+		wantErr      error
+		wantInstalls bool
+	}{
+		{
+			name:         "archive with .sha256 file",
+			fileName:     archiveName,
+			content:      archive,
+			checksum:     sha256Hex(archive) + "  " + archiveName + "\n",
+			wantInstalls: true,
+		},
+		{
+			name:     "archive with manifest",
+			fileName: archiveName,
+			content:  archive,
+			checksum: strings.Repeat("0", 64) + "  other.tar.gz\n" +
+				sha256Hex(archive) + "  " + archiveName + "\n",
+			wantInstalls: true,
+		},
+		{
+			name:         "bare binary",
+			fileName:     "golangci-lint",
+			content:      fakeBinary,
+			checksum:     sha256Hex(fakeBinary),
+			wantInstalls: true,
+		},
+		{
+			name:         "frozen bare binary with checksum file",
+			fileName:     "golangci-lint",
+			content:      fakeBinary,
+			checksum:     sha256Hex(fakeBinary),
+			frozen:       true,
+			wantInstalls: true,
+		},
+		{
+			name:     "frozen bare binary without checksum file",
+			fileName: "golangci-lint",
+			content:  fakeBinary,
+			frozen:   true,
+			wantErr:  ErrChecksumUnavailable,
+		},
+		{
+			name:     "strict bare binary without checksum file",
+			fileName: "golangci-lint",
+			content:  fakeBinary,
+			strict:   true,
+			wantErr:  ErrChecksumUnavailable,
+		},
+		{
+			name:         "no checksum file",
+			fileName:     archiveName,
+			content:      archive,
+			wantInstalls: true,
+		},
+		{
+			name:     "strict without checksum file",
+			fileName: archiveName,
+			content:  archive,
+			strict:   true,
+			wantErr:  ErrChecksumUnavailable,
+		},
+		{
+			name:     "manifest without the archive",
+			fileName: archiveName,
+			content:  archive,
+			checksum: sha256Hex(archive) + "  other.tar.gz\n" +
+				strings.Repeat("0", 64) + "  another.tar.gz\n",
+			wantErr: ErrChecksumNotFound,
+		},
+		{
+			name:     "checksum mismatch",
+			fileName: archiveName,
+			content:  archive,
+			checksum: strings.Repeat("0", 64),
+			wantErr:  ErrChecksumMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dl := newTestDownloader(t, Options{RequireChecksum: test.strict, Frozen: test.frozen})
+
+			dir := t.TempDir()
+			filePath := filepath.Join(dir, test.fileName)
+
+			if err := os.WriteFile(filePath, test.content, filePermission); err != nil {
+				t.Fatalf("failed to write %s: %v", filePath, err)
+			}
+
+			checksumFile := ""
+			if test.checksum != "" {
+				checksumFile = filepath.Join(dir, "checksums.txt")
+				if err := os.WriteFile(checksumFile, []byte(test.checksum), filePermission); err != nil {
+					t.Fatalf("failed to write checksum file: %v", err)
+				}
+			}
+
+			err := dl.InstallFromFile(context.Background(), filePath, "1.55.2", checksumFile)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("InstallFromFile() error = %v, want %v", err, test.wantErr)
+			}
+
+			if got := dl.cacheManager.IsCached(testVersion); got != test.wantInstalls {
+				t.Fatalf("IsCached() = %v, want %v", got, test.wantInstalls)
+			}
+
+			if test.wantInstalls {
+				assertFileContent(t, dl.cacheManager.GetBinaryPath(testVersion), fakeBinary)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+// FrozenEnvVar is the environment variable requiring installs to match the lockfile.
+const FrozenEnvVar = "GLINT_VM_FROZEN"
+
+// FetchChecksums fetches the release archive checksum of a version for each platform
+// (e.g. linux-amd64), trying mirrors or the release source in order. Platforms without an asset are skipped.
+func (d *Downloader) FetchChecksums(ctx context.Context, version string, platforms []string) (map[string]string, error) {
+	checksums := make(map[string]string, len(platforms))
+
+	for _, platform := range platforms {
+		goos, goarch, err := config.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+
+		mirrors, err := d.assetMirrors(ctx, version, goos, goarch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to fetch checksums: %w", ctx.Err())
+			}
+
+			fmt.Fprintf(os.Stderr, "Warning: %v, skipping platform\n", err)
+
+			continue
+		}
+
+		for _, mirror := range mirrors {
+			hash, _, err := d.fetchExpectedChecksum(ctx, mirror, version, goos, goarch)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, fmt.Errorf("failed to fetch checksums: %w", ctx.Err())
+				}
+
+				continue
+			}
+
+			checksums[platform] = hash
+
+			break
+		}
+
+		if _, ok := checksums[platform]; !ok {
+			fmt.Fprintf(os.Stderr, "Warning: no checksum found for %s %s, skipping platform\n", version, platform)
+		}
+	}
+
+	if len(checksums) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrChecksumUnavailable, version)
+	}
+
+	return checksums, nil
+}
+
+// lockedChecksum returns the archive checksum pinned by the lockfile for a version on the
+// current platform. An empty checksum means the lockfile doesn't apply; in frozen mode this is an error.
+// When the lockfile only pins the emulated platform, the checksum is resolved once the install knows
+// whether it falls back to it (see pinsEmulatedOnly).
+func (d *Downloader) lockedChecksum(version string) (string, error) {
+	if d.pinsEmulatedOnly(version) {
+		return "", nil
+	}
+
+	return d.lockedChecksumFor(version, d.config.GetPlatformString())
+}
+
+// pinsEmulatedOnly reports whether the Rosetta fallback applies and the lockfile pins a version
+// for the emulated platform only, as for releases predating native archives.
+func (d *Downloader) pinsEmulatedOnly(version string) bool {
+	platform, ok := d.emulatedPlatform()
+	if !ok || d.lock == nil || d.lock.Version != version {
+		return false
+	}
+
+	_, native := d.lock.Checksum(d.config.GetPlatformString())
+	_, emulated := d.lock.Checksum(platform)
+
+	return !native && emulated
+}
+
+// lockedChecksumFor returns the archive checksum pinned by the lockfile for a version on a platform.
+func (d *Downloader) lockedChecksumFor(version, platform string) (string, error) {
+	if d.lock == nil {
+		if d.frozen {
+			return "", fmt.Errorf("%w: run 'glint-vm lock' to create %s", ErrLockMissing, lockfile.FileName)
+		}
+
+		return "", nil
+	}
+
+	if d.lock.Version != version {
+		if d.frozen {
+			return "", fmt.Errorf("%w: %s pins %s, not %s", ErrLockStale, lockfile.FileName, d.lock.Version, version)
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: %s pins %s, not verifying %s against it\n", lockfile.FileName, d.lock.Version, version)
+
+		return "", nil
+	}
+
+	checksum, ok := d.lock.Checksum(platform)
+	if !ok {
+		if d.frozen {
+			return "", fmt.Errorf("%w: %s has no checksum for %s", ErrLockStale, lockfile.FileName, platform)
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: %s has no checksum for %s\n", lockfile.FileName, platform)
+
+		return "", nil
+	}
+
+	return checksum, nil
+}
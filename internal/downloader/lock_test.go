@@ -0,0 +1,150 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+func TestDownload_Lockfile(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+	wrongHash := strings.Repeat("0", 64)
+
+	tests := []struct {
+		name    string
+		lock    *lockfile.Lockfile
+		frozen  bool
+		wantErr error
+	}{
+		{
+			name: "lock overrides server checksum",
+			lock: &lockfile.Lockfile{Version: testVersion, Checksums: map[string]string{platform: sha256Hex(archive)}},
+		},
+		{
+			name:    "lock mismatch",
+			lock:    &lockfile.Lockfile{Version: testVersion, Checksums: map[string]string{platform: wrongHash}},
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name: "stale lock falls back to server checksum",
+			lock: &lockfile.Lockfile{Version: "v1.54.0", Checksums: map[string]string{platform: wrongHash}},
+			// The server checksum is wrong on purpose, proving it was used.
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "frozen with stale lock",
+			lock:    &lockfile.Lockfile{Version: "v1.54.0", Checksums: map[string]string{platform: sha256Hex(archive)}},
+			frozen:  true,
+			wantErr: ErrLockStale,
+		},
+		{
+			name:    "frozen with missing platform",
+			lock:    &lockfile.Lockfile{Version: testVersion, Checksums: map[string]string{"plan9-mips": sha256Hex(archive)}},
+			frozen:  true,
+			wantErr: ErrLockStale,
+		},
+		{
+			name:    "frozen without lock",
+			frozen:  true,
+			wantErr: ErrLockMissing,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The server publishes a wrong checksum: only the lockfile can make the download succeed.
+			server := newReleaseServer(t, map[string][]byte{
+				releaseAssetPath(testVersion):             archive,
+				releaseAssetPath(testVersion) + ".sha256": []byte(wrongHash),
+			})
+
+			mirrors, err := ParseMirrors([]string{server.URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{Mirrors: mirrors, Lock: test.lock, Frozen: test.frozen})
+
+			err = dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDownload_LockfileCachedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		frozen  bool
+		wantErr error
+	}{
+		{name: "stale lock ignored"},
+		{name: "frozen with stale lock", frozen: true, wantErr: ErrLockStale},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			archive := buildTarball(t, testVersion, fakeBinary)
+
+			server := newReleaseServer(t, map[string][]byte{
+				releaseAssetPath(testVersion):             archive,
+				releaseAssetPath(testVersion) + ".sha256": []byte(sha256Hex(archive)),
+			})
+
+			mirrors, err := ParseMirrors([]string{server.URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+			if err := dl.Download(context.Background(), testVersion); err != nil {
+				t.Fatalf("Download() failed: %v", err)
+			}
+
+			// The lockfile is only looked up for downloads, except in frozen mode
+			dl.lock = &lockfile.Lockfile{Version: "v1.54.0", Checksums: map[string]string{"linux-amd64": sha256Hex(archive)}}
+			dl.frozen = test.frozen
+
+			err = dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFetchChecksums(t *testing.T) {
+	manifest := "1111111111111111111111111111111111111111111111111111111111111111  golangci-lint-1.55.2-linux-amd64.tar.gz\n" +
+		"2222222222222222222222222222222222222222222222222222222222222222  golangci-lint-1.55.2-darwin-amd64.tar.gz\n"
+
+	server := newReleaseServer(t, map[string][]byte{
+		manifestPath(testVersion): []byte(manifest),
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	got, err := dl.FetchChecksums(context.Background(), testVersion, lockfile.DefaultPlatforms())
+	if err != nil {
+		t.Fatalf("FetchChecksums() failed: %v", err)
+	}
+
+	if len(got) != 2 || got["linux-amd64"] != strings.Repeat("1", 64) || got["darwin-amd64"] != strings.Repeat("2", 64) {
+		t.Errorf("FetchChecksums() = %v", got)
+	}
+
+	if _, err := dl.FetchChecksums(context.Background(), "v9.9.9", lockfile.DefaultPlatforms()); !errors.Is(err, ErrChecksumUnavailable) {
+		t.Errorf("FetchChecksums() error = %v, want %v", err, ErrChecksumUnavailable)
+	}
+}
@@ -0,0 +1,163 @@
+package downloader
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MirrorsEnvVar is the environment variable holding a comma-separated list of mirrors.
+	MirrorsEnvVar = "GLINT_VM_MIRRORS"
+
+	defaultArchiveTemplate  = "{tag}/golangci-lint-{version}-{platform}.{ext}"
+	defaultChecksumTemplate = "{archive}.sha256"
+	defaultManifestTemplate = "{tag}/golangci-lint-{version}-checksums.txt"
+	canonicalAssetTemplate  = "golangci-lint-{version}-{platform}.{ext}"
+)
+
+// Mirror describes a location serving golangci-lint release assets.
+//
+// Templates are expanded with the following placeholders:
+//   - {tag}: the release tag (v1.55.2)
+//   - {version}: the version without the "v" prefix (1.55.2)
+//   - {os}, {arch}, {platform}: the target platform (linux, amd64, linux-amd64)
+//   - {ext}: the archive extension (zip on Windows, tar.gz elsewhere)
+//   - {archive}: the expanded archive path (checksum template only)
+type Mirror struct {
+	// BaseURL is the URL the archive and checksum paths are appended to.
+	BaseURL string
+	// APIURL is the GitHub-compatible releases API endpoint, used by list-remote.
+	// Mirrors without an API URL are skipped when listing versions.
+	APIURL string
+	// ArchiveTemplate is the path of the archive relative to BaseURL.
+	ArchiveTemplate string
+	// ChecksumTemplate is the path of the per-archive checksum file relative to BaseURL.
+	ChecksumTemplate string
+	// ManifestTemplate is the path of the release-wide checksums manifest relative to BaseURL.
+	// Mirrors without a manifest only use the per-archive checksum file.
+	ManifestTemplate string
+
+	// archiveURL overrides the archive location, for sources listing assets by URL.
+	archiveURL string
+	// sha256 is the expected archive checksum, when the source lists it.
+	sha256 string
+}
+
+// DefaultMirror returns the public GitHub releases mirror.
+func DefaultMirror() Mirror {
+	return newGitHubSource(gitHubURL, gitHubAPIURL, DefaultRepo, "").mirror
+}
+
+// ParseMirrors parses mirror specifications.
+//
+// Each specification is a base URL optionally followed by semicolon-separated
+// key=value options, e.g.:
+//
+//	https://artifactory.example.com/golangci;archive={tag}/{platform}.{ext};checksum={archive}.sha256;manifest={tag}/checksums.txt;api=https://artifactory.example.com/api/releases
+//
+// An empty list yields no mirror: assets are then downloaded from the release source.
+func ParseMirrors(specs []string) ([]Mirror, error) {
+	mirrors := make([]Mirror, 0, len(specs))
+
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		mirror, err := parseMirror(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		mirrors = append(mirrors, mirror)
+	}
+
+	return mirrors, nil
+}
+
+func parseMirror(spec string) (Mirror, error) {
+	parts := strings.Split(spec, ";")
+
+	mirror := Mirror{
+		BaseURL:          strings.TrimSuffix(strings.TrimSpace(parts[0]), "/"),
+		ArchiveTemplate:  defaultArchiveTemplate,
+		ChecksumTemplate: defaultChecksumTemplate,
+		ManifestTemplate: defaultManifestTemplate,
+	}
+
+	if !strings.HasPrefix(mirror.BaseURL, "http://") && !strings.HasPrefix(mirror.BaseURL, "https://") {
+		return Mirror{}, fmt.Errorf("%w: %q: base URL must be http(s)", ErrInvalidMirror, spec)
+	}
+
+	for _, option := range parts[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(option), "=")
+		if !ok || value == "" {
+			return Mirror{}, fmt.Errorf("%w: %q: malformed option %q", ErrInvalidMirror, spec, option)
+		}
+
+		switch key {
+		case "archive":
+			mirror.ArchiveTemplate = strings.TrimPrefix(value, "/")
+		case "checksum":
+			mirror.ChecksumTemplate = strings.TrimPrefix(value, "/")
+		case "manifest":
+			mirror.ManifestTemplate = strings.TrimPrefix(value, "/")
+		case "api":
+			mirror.APIURL = value
+		default:
+			return Mirror{}, fmt.Errorf("%w: %q: unknown option %q", ErrInvalidMirror, spec, key)
+		}
+	}
+
+	return mirror, nil
+}
+
+// ArchiveURL returns the archive URL for a version and platform on this mirror.
+func (m Mirror) ArchiveURL(version, goos, goarch string) string {
+	if m.archiveURL != "" {
+		return m.archiveURL
+	}
+
+	return m.BaseURL + "/" + m.expand(m.ArchiveTemplate, version, goos, goarch, "")
+}
+
+// ChecksumURL returns the checksum file URL for a version and platform on this mirror.
+func (m Mirror) ChecksumURL(version, goos, goarch string) string {
+	if m.archiveURL != "" {
+		return m.expand(m.ChecksumTemplate, version, goos, goarch, m.archiveURL)
+	}
+
+	archive := m.expand(m.ArchiveTemplate, version, goos, goarch, "")
+
+	return m.BaseURL + "/" + m.expand(m.ChecksumTemplate, version, goos, goarch, archive)
+}
+
+// ManifestURL returns the checksums manifest URL for a version on this mirror,
+// or an empty string when the mirror has no manifest.
+func (m Mirror) ManifestURL(version, goos, goarch string) string {
+	if m.ManifestTemplate == "" {
+		return ""
+	}
+
+	return m.BaseURL + "/" + m.expand(m.ManifestTemplate, version, goos, goarch, "")
+}
+
+// assetName returns the upstream release asset file name, as listed in checksums manifests.
+func assetName(version, goos, goarch string) string {
+	return Mirror{}.expand(canonicalAssetTemplate, version, goos, goarch, "")
+}
+
+func (m Mirror) expand(template, version, goos, goarch, archive string) string {
+	replacer := strings.NewReplacer(
+		"{tag}", version,
+		"{version}", strings.TrimPrefix(version, "v"),
+		"{os}", goos,
+		"{arch}", goarch,
+		"{platform}", goos+"-"+goarch,
+		"{ext}", archiveExtension(goos),
+		"{archive}", archive,
+	)
+
+	return replacer.Replace(template)
+}
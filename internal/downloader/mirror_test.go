@@ -0,0 +1,111 @@
+package downloader
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMirrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		specs   []string
+		want    []Mirror
+		wantErr error
+	}{
+		{
+			name:  "empty list leaves downloads to the release source",
+			specs: nil,
+			want:  []Mirror{},
+		},
+		{
+			name:  "base URL only",
+			specs: []string{"https://mirror.example.com/golangci/"},
+			want: []Mirror{{
+				BaseURL:          "https://mirror.example.com/golangci",
+				ArchiveTemplate:  defaultArchiveTemplate,
+				ChecksumTemplate: defaultChecksumTemplate,
+				ManifestTemplate: defaultManifestTemplate,
+			}},
+		},
+		{
+			name: "with options",
+			specs: []string{
+				"https://a.example.com;archive=/{version}/{platform}.{ext};checksum={archive}.sum;manifest={tag}/SHA256SUMS;api=https://a.example.com/api",
+				"https://b.example.com",
+			},
+			want: []Mirror{
+				{
+					BaseURL:          "https://a.example.com",
+					APIURL:           "https://a.example.com/api",
+					ArchiveTemplate:  "{version}/{platform}.{ext}",
+					ChecksumTemplate: "{archive}.sum",
+					ManifestTemplate: "{tag}/SHA256SUMS",
+				},
+				{
+					BaseURL:          "https://b.example.com",
+					ArchiveTemplate:  defaultArchiveTemplate,
+					ChecksumTemplate: defaultChecksumTemplate,
+					ManifestTemplate: defaultManifestTemplate,
+				},
+			},
+		},
+		{
+			name:    "not http",
+			specs:   []string{"ftp://mirror.example.com"},
+			wantErr: ErrInvalidMirror,
+		},
+		{
+			name:    "unknown option",
+			specs:   []string{"https://mirror.example.com;foo=bar"},
+			wantErr: ErrInvalidMirror,
+		},
+		{
+			name:    "malformed option",
+			specs:   []string{"https://mirror.example.com;archive"},
+			wantErr: ErrInvalidMirror,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseMirrors(test.specs)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("ParseMirrors() error = %v, want %v", err, test.wantErr)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("ParseMirrors() returned %d mirrors, want %d", len(got), len(test.want))
+			}
+
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("ParseMirrors()[%d] = %+v, want %+v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMirror_URLs(t *testing.T) {
+	t.Parallel()
+
+	mirror := DefaultMirror()
+
+	wantArchive := "https://github.com/golangci/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz"
+	if got := mirror.ArchiveURL(testVersion, "linux", "amd64"); got != wantArchive {
+		t.Errorf("ArchiveURL() = %s, want %s", got, wantArchive)
+	}
+
+	if got := mirror.ChecksumURL(testVersion, "linux", "amd64"); got != wantArchive+".sha256" {
+		t.Errorf("ChecksumURL() = %s, want %s", got, wantArchive+".sha256")
+	}
+
+	wantManifest := "https://github.com/golangci/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-checksums.txt"
+	if got := mirror.ManifestURL(testVersion, "linux", "amd64"); got != wantManifest {
+		t.Errorf("ManifestURL() = %s, want %s", got, wantManifest)
+	}
+}
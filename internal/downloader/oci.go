@@ -0,0 +1,612 @@
+package downloader
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+	"github.com/youkoulayley/glint-vm/internal/detector"
+)
+
+const (
+	// ociBinaryPath is where the golangci-lint images ship the binary.
+	ociBinaryPath = "usr/bin/golangci-lint"
+	// ociWhiteoutPath marks the binary as deleted by an upper layer.
+	ociWhiteoutPath = "usr/bin/.wh.golangci-lint"
+
+	ociTagsPerPage     = 1000
+	maxOCIManifestSize = 4 * 1024 * 1024
+	maxOCILayerSize    = 2 * 1024 * 1024 * 1024
+	maxOCITokenSize    = 1024 * 1024
+
+	ociManifestAccept = "application/vnd.oci.image.index.v1+json, " +
+		"application/vnd.docker.distribution.manifest.list.v2+json, " +
+		"application/vnd.oci.image.manifest.v1+json, " +
+		"application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// ociSource is a release source backed by golangci/golangci-lint images in an OCI registry
+// (Docker Registry HTTP API v2). Only the golangci-lint binary is extracted from the image.
+type ociSource struct {
+	// registry is the registry base URL, e.g. https://registry.example.com.
+	registry string
+	// repo is the image repository, e.g. golangci/golangci-lint.
+	repo string
+	// username and password are used for basic auth and to request bearer tokens.
+	username string
+	password string
+
+	mu sync.Mutex
+	// authorization is the Authorization header obtained from the last challenge.
+	authorization string
+}
+
+// ociDescriptor references a manifest or layer by digest.
+type ociDescriptor struct {
+	MediaType string       `json:"mediaType"`
+	Digest    string       `json:"digest"`
+	Size      int64        `json:"size"`
+	Platform  *ociPlatform `json:"platform,omitempty"`
+}
+
+type ociPlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// matches reports whether an image platform can run on the downloader's platform.
+// arm images carry the ARM version as variant (v6, v7).
+func (p *ociPlatform) matches(cfg *config.Config) bool {
+	if p == nil || p.OS != cfg.OS || p.Architecture != cfg.Arch {
+		return false
+	}
+
+	return cfg.ARM == "" || p.Variant == "" || p.Variant == "v"+cfg.ARM
+}
+
+// ociManifest is an image manifest, or an image index listing per-platform manifests.
+type ociManifest struct {
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// newOCISource returns the source pulling golangci-lint images from a registry.
+func newOCISource(registry, repo, username, password string) *ociSource {
+	return &ociSource{
+		registry: registry,
+		repo:     repo,
+		username: username,
+		password: password,
+	}
+}
+
+// Name implements ReleaseSource.
+func (s *ociSource) Name() string {
+	return s.registry + "/v2/" + s.repo
+}
+
+// assetMirror implements ReleaseSource. Registries serve images, not release archives.
+func (s *ociSource) assetMirror(_ context.Context, _ *Downloader, version, _, _ string) (Mirror, error) {
+	return Mirror{}, fmt.Errorf("%w: %s serves images, not release archives of %s", ErrAssetNotFound, s.Name(), version)
+}
+
+// fetchIndex implements ReleaseSource by listing the image tags named after a version.
+func (s *ociSource) fetchIndex(ctx context.Context, d *Downloader, _ *releaseIndex) (*releaseIndex, error) {
+	var releases []Release
+
+	pageURL := fmt.Sprintf("%s/tags/list?n=%d", s.Name(), ociTagsPerPage)
+
+	for pages := 0; pageURL != "" && pages < maxReleasePages; pages++ {
+		var (
+			tags []string
+			next string
+		)
+
+		err := d.retry.do(ctx, func() error {
+			var err error
+
+			tags, next, err = s.fetchTags(ctx, d, pageURL)
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags {
+			if version := imageTagVersion(tag); version != "" {
+				releases = append(releases, Release{TagName: version, Name: tag})
+			}
+		}
+
+		pageURL = next
+	}
+
+	sortReleasesNewestFirst(releases)
+
+	return &releaseIndex{Source: s.Name(), FetchedAt: time.Now(), Releases: releases}, nil
+}
+
+// imageTagVersion returns the version an image tag stands for, as understood by the
+// docker-image detection pattern, or an empty string for other tags (latest, v1.55.2-alpine, ...).
+func imageTagVersion(tag string) string {
+	version := detector.GetDockerImagePattern().ExtractVersion(DefaultRepo + ":" + tag)
+	if version == "" || config.NormalizeVersion(tag) != version {
+		return ""
+	}
+
+	return version
+}
+
+// fetchTags fetches a page of the repository tags and the URL of the next page, if any.
+func (s *ociSource) fetchTags(ctx context.Context, d *Downloader, pageURL string) ([]string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	resp, err := s.get(ctx, d, pageURL, "application/json")
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", s.statusError(pageURL, resp)
+	}
+
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOCIManifestSize)).Decode(&list); err != nil {
+		return nil, "", fmt.Errorf("failed to decode tags: %w: %w", errTransferFailed, err)
+	}
+
+	// Registries return a Link relative to the registry root
+	next := nextPageURL(resp.Header.Get("Link"))
+	if next != "" {
+		next, err = resolveURL(pageURL, next)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return list.Tags, next, nil
+}
+
+// installBinary implements binarySource: it pulls the image of a version for the
+// downloader's platform and extracts the golangci-lint binary into destDir.
+// Layers are verified against their digest before being read.
+func (s *ociSource) installBinary(ctx context.Context, d *Downloader, version, stagingDir, destDir string) error {
+	manifest, err := s.platformManifest(ctx, d, version)
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(destDir, d.config.BinaryName())
+
+	// Upper layers override lower ones
+	for i := len(manifest.Layers) - 1; i >= 0; i-- {
+		layer := manifest.Layers[i]
+
+		layerPath, err := s.fetchLayer(ctx, d, layer, stagingDir)
+		if err != nil {
+			return err
+		}
+
+		found, deleted, err := d.extractImageBinary(layerPath, target)
+
+		_ = os.Remove(layerPath)
+
+		if err != nil {
+			return fmt.Errorf("failed to extract layer %s: %w", layer.Digest, err)
+		}
+
+		if found {
+			fmt.Fprintf(os.Stderr, "✓ Extracted %s from %s:%s\n", ociBinaryPath, s.repo, version)
+
+			return nil
+		}
+
+		if deleted {
+			break
+		}
+	}
+
+	return fmt.Errorf("%w: %s not in image %s:%s", ErrBinaryNotFound, ociBinaryPath, s.repo, version)
+}
+
+// platformManifest returns the image manifest of a version for the downloader's platform.
+func (s *ociSource) platformManifest(ctx context.Context, d *Downloader, version string) (*ociManifest, error) {
+	manifest, err := s.fetchManifest(ctx, d, version)
+
+	// Tags may omit the "v" prefix
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		manifest, err = s.fetchManifest(ctx, d, strings.TrimPrefix(version, "v"))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(manifest.Manifests) == 0 {
+		// Single-platform image, golangci-lint images are Linux only
+		if d.config.OS != "linux" {
+			return nil, fmt.Errorf("%w: image %s:%s is linux only, not %s", ErrAssetNotFound, s.repo, version, d.config.GetPlatformString())
+		}
+
+		return manifest, nil
+	}
+
+	for _, descriptor := range manifest.Manifests {
+		if descriptor.Platform.matches(d.config) {
+			return s.fetchManifest(ctx, d, descriptor.Digest)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: image %s:%s has no %s variant", ErrAssetNotFound, s.repo, version, d.config.GetPlatformString())
+}
+
+// fetchManifest fetches a manifest by tag or digest, retrying transient failures.
+func (s *ociSource) fetchManifest(ctx context.Context, d *Downloader, reference string) (*ociManifest, error) {
+	manifestURL := s.Name() + "/manifests/" + reference
+
+	var manifest ociManifest
+
+	err := d.retry.do(ctx, func() error {
+		ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+		defer cancel()
+
+		resp, err := s.get(ctx, d, manifestURL, ociManifestAccept)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return s.statusError(manifestURL, resp)
+		}
+
+		content, err := io.ReadAll(io.LimitReader(resp.Body, maxOCIManifestSize))
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %w: %w", errTransferFailed, err)
+		}
+
+		if strings.HasPrefix(reference, "sha256:") && sha256Digest(content) != reference {
+			return fmt.Errorf("%w: manifest %s", ErrChecksumMismatch, reference)
+		}
+
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			return fmt.Errorf("failed to decode manifest %s: %w", reference, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// fetchLayer downloads a layer blob into dir and verifies its digest.
+func (s *ociSource) fetchLayer(ctx context.Context, d *Downloader, layer ociDescriptor, dir string) (string, error) {
+	blobURL := s.Name() + "/blobs/" + layer.Digest
+
+	var layerPath string
+
+	err := d.retry.do(ctx, func() error {
+		resp, err := s.get(ctx, d, blobURL, "")
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return s.statusError(blobURL, resp)
+		}
+
+		file, err := os.CreateTemp(dir, "layer-*")
+		if err != nil {
+			return fmt.Errorf("failed to create layer file: %w", err)
+		}
+
+		hash := sha256.New()
+
+		_, err = io.Copy(io.MultiWriter(file, hash), io.LimitReader(resp.Body, maxOCILayerSize))
+
+		err = errors.Join(err, file.Close())
+		if err != nil {
+			_ = os.Remove(file.Name())
+
+			return fmt.Errorf("failed to download layer %s: %w: %w", layer.Digest, errTransferFailed, err)
+		}
+
+		if digest := "sha256:" + hex.EncodeToString(hash.Sum(nil)); digest != layer.Digest {
+			_ = os.Remove(file.Name())
+
+			return fmt.Errorf("%w: layer %s, got %s", ErrChecksumMismatch, layer.Digest, digest)
+		}
+
+		layerPath = file.Name()
+
+		return nil
+	})
+
+	return layerPath, err
+}
+
+// extractImageBinary extracts the golangci-lint binary from a layer to target.
+// It reports whether the binary was found, or deleted by a whiteout entry.
+func (d *Downloader) extractImageBinary(layerPath, target string) (bool, bool, error) {
+	file, err := os.Open(layerPath) //nolint:gosec // Path is internally controlled
+	if err != nil {
+		return false, false, fmt.Errorf("failed to open layer: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	reader := bufio.NewReader(file)
+
+	var layer io.Reader = reader
+
+	magic, _ := reader.Peek(4)
+
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gzr, err := gzip.NewReader(reader)
+		if err != nil {
+			return false, false, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+
+		defer func() { _ = gzr.Close() }()
+
+		layer = gzr
+	case bytes.Equal(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return false, false, fmt.Errorf("%w: zstd", ErrUnsupportedLayer)
+	}
+
+	tr := tar.NewReader(layer)
+	deleted := false
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return false, deleted, nil
+		}
+
+		if err != nil {
+			return false, false, fmt.Errorf("failed to read tar header: %w", err)
+		}
+
+		switch strings.TrimPrefix(path.Clean("/"+header.Name), "/") {
+		case ociBinaryPath:
+			if header.Typeflag != tar.TypeReg {
+				return false, false, fmt.Errorf("%w: %s is not a regular file", ErrUnsafeEntry, header.Name)
+			}
+
+			if err := d.extractFile(tr, target, header.Size, executablePermission); err != nil {
+				return false, false, err
+			}
+
+			return true, false, nil
+		case ociWhiteoutPath:
+			deleted = true
+		}
+	}
+}
+
+// get sends a GET request to the registry, answering its authentication challenge if needed.
+func (s *ociSource) get(ctx context.Context, d *Downloader, target, accept string) (*http.Response, error) {
+	resp, err := s.send(ctx, d, target, accept)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+
+	_ = resp.Body.Close()
+
+	if err := s.authenticate(ctx, d, challenge); err != nil {
+		return nil, err
+	}
+
+	return s.send(ctx, d, target, accept)
+}
+
+func (s *ociSource) send(ctx context.Context, d *Downloader, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	s.mu.Lock()
+	authorization := s.authorization
+	s.mu.Unlock()
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	//nolint:gosec // URL is constructed from the source configuration
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("registry request failed: %w: %w", errRequestFailed, err)
+	}
+
+	return resp, nil
+}
+
+// authenticate answers a WWW-Authenticate challenge with basic credentials or a bearer token.
+func (s *ociSource) authenticate(ctx context.Context, d *Downloader, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+
+	var authorization string
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if s.username == "" {
+			return fmt.Errorf("%w: %s requires credentials", ErrRegistryAuth, s.registry)
+		}
+
+		authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(s.username+":"+s.password))
+	case "bearer":
+		token, err := s.fetchToken(ctx, d, params)
+		if err != nil {
+			return err
+		}
+
+		authorization = "Bearer " + token
+	default:
+		return fmt.Errorf("%w: unsupported challenge %q", ErrRegistryAuth, challenge)
+	}
+
+	s.mu.Lock()
+	s.authorization = authorization
+	s.mu.Unlock()
+
+	return nil
+}
+
+// fetchToken requests a bearer token from the realm of a challenge, anonymously or with basic credentials.
+func (s *ociSource) fetchToken(ctx context.Context, d *Downloader, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("%w: invalid token realm %q", ErrRegistryAuth, params["realm"])
+	}
+
+	query := realm.Query()
+
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+
+	realm.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	//nolint:gosec // Realm comes from the configured registry
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w: %w", errRequestFailed, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: token endpoint answered %d", ErrRegistryAuth, resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOCITokenSize)).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %w: %w", errTransferFailed, err)
+	}
+
+	if token.Token != "" {
+		return token.Token, nil
+	}
+
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+
+	return "", fmt.Errorf("%w: empty token", ErrRegistryAuth)
+}
+
+// statusError converts an unexpected registry response to an error.
+func (s *ociSource) statusError(target string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: %s answered %d", ErrRegistryAuth, target, resp.StatusCode)
+	}
+
+	return newStatusError(target, resp)
+}
+
+// parseChallenge parses a WWW-Authenticate header such as
+// Bearer realm="https://auth.example.com/token",service="registry",scope="repository:x:pull".
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		var key, value string
+
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+
+	return scheme, params
+}
+
+// resolveURL resolves a possibly relative reference against a base URL.
+func resolveURL(base, reference string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", base, err)
+	}
+
+	ref, err := url.Parse(reference)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", reference, err)
+	}
+
+	return baseURL.ResolveReference(ref).String(), nil
+}
+
+// sha256Digest returns the OCI digest of content.
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,343 @@
+package downloader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/detector"
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+// buildLayer returns a gzipped tar layer holding files, keyed by path.
+func buildLayer(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+
+		if _, err := tw.Write(files[name]); err != nil {
+			t.Fatalf("Failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer: %v", err)
+	}
+
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// testRegistry is an httptest stand-in for a Docker Registry v2 requiring bearer tokens.
+type testRegistry struct {
+	server    *httptest.Server
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      []string
+	tokenAuth string
+}
+
+func newTestRegistry(t *testing.T, layers ...[]byte) *testRegistry {
+	t.Helper()
+
+	registry := &testRegistry{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string][]byte),
+		tags:      []string{"v1.54.0", "latest", testVersion, "v1.55.2-alpine", "1.56.0"},
+	}
+
+	var descriptors []ociDescriptor
+
+	for _, layer := range layers {
+		digest := sha256Digest(layer)
+		registry.blobs[digest] = layer
+		descriptors = append(descriptors, ociDescriptor{
+			MediaType: "application/vnd.oci.image.layer.v1.tar+gzip",
+			Digest:    digest,
+			Size:      int64(len(layer)),
+		})
+	}
+
+	image, _ := json.Marshal(ociManifest{Layers: descriptors})
+	other, _ := json.Marshal(ociManifest{})
+	registry.manifests[sha256Digest(image)] = image
+	registry.manifests[sha256Digest(other)] = other
+
+	index, _ := json.Marshal(ociManifest{Manifests: []ociDescriptor{
+		{Digest: sha256Digest(other), Platform: &ociPlatform{OS: "linux", Architecture: "arm64"}},
+		{Digest: sha256Digest(image), Platform: &ociPlatform{OS: "linux", Architecture: "amd64"}},
+	}})
+	registry.manifests[testVersion] = index
+
+	registry.server = httptest.NewServer(http.HandlerFunc(registry.serveHTTP))
+	t.Cleanup(registry.server.Close)
+
+	return registry
+}
+
+func (r *testRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		r.tokenAuth = req.Header.Get("Authorization")
+
+		if req.URL.Query().Get("scope") != "repository:golangci/golangci-lint:pull" {
+			http.Error(w, "bad scope", http.StatusBadRequest)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "registry-token"})
+
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer registry-token" {
+		w.Header().Set("WWW-Authenticate",
+			`Bearer realm="`+r.server.URL+`/token",service="test",scope="repository:golangci/golangci-lint:pull"`)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	const prefix = "/v2/golangci/golangci-lint/"
+
+	switch name := strings.TrimPrefix(req.URL.Path, prefix); {
+	case name == "tags/list" && req.URL.Query().Get("last") == "":
+		w.Header().Set("Link", `</v2/golangci/golangci-lint/tags/list?last=v1.55.2&n=1000>; rel="next"`)
+		_ = json.NewEncoder(w).Encode(map[string]any{"tags": r.tags[:3]})
+	case name == "tags/list":
+		_ = json.NewEncoder(w).Encode(map[string]any{"tags": r.tags[3:]})
+	case strings.HasPrefix(name, "manifests/") && r.manifests[strings.TrimPrefix(name, "manifests/")] != nil:
+		_, _ = w.Write(r.manifests[strings.TrimPrefix(name, "manifests/")])
+	case strings.HasPrefix(name, "blobs/") && r.blobs[strings.TrimPrefix(name, "blobs/")] != nil:
+		_, _ = w.Write(r.blobs[strings.TrimPrefix(name, "blobs/")])
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func newOCITestDownloader(t *testing.T, registry *testRegistry) *Downloader {
+	t.Helper()
+
+	source, err := NewReleaseSource(SourceConfig{Kind: SourceOCI, URL: registry.server.URL, Username: "ci", Password: "secret"})
+	if err != nil {
+		t.Fatalf("NewReleaseSource() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Source: source, Retry: fastRetry(1)})
+	dl.config.OS = "linux"
+	dl.config.Arch = "amd64"
+
+	return dl
+}
+
+func TestOCISource(t *testing.T) {
+	base := buildLayer(t, map[string][]byte{"usr/bin/sh": []byte("sh"), "usr/bin/golangci-lint": []byte("old")})
+	top := buildLayer(t, map[string][]byte{"usr/bin/golangci-lint": fakeBinary, "etc/os-release": []byte("")})
+	registry := newTestRegistry(t, base, top)
+
+	dl := newOCITestDownloader(t, registry)
+
+	releases, err := dl.FetchAvailableVersions(context.Background(), ReleaseFilter{})
+	if err != nil {
+		t.Fatalf("FetchAvailableVersions() failed: %v", err)
+	}
+
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+
+	if want := []string{"v1.56.0", testVersion, "v1.54.0"}; !slices.Equal(tags, want) {
+		t.Errorf("FetchAvailableVersions() = %v, want %v", tags, want)
+	}
+
+	if registry.tokenAuth == "" {
+		t.Error("token request should carry the registry credentials")
+	}
+
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	content, err := os.ReadFile(dl.cacheManager.GetBinaryPath(testVersion))
+	if err != nil {
+		t.Fatalf("Failed to read installed binary: %v", err)
+	}
+
+	if !bytes.Equal(content, fakeBinary) {
+		t.Errorf("installed binary = %q, want the one from the top layer", content)
+	}
+}
+
+func TestOCISource_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		layers  [][]byte
+		arch    string
+		tamper  bool
+		wantErr error
+	}{
+		{
+			name:    "binary missing",
+			layers:  [][]byte{buildLayer(t, map[string][]byte{"usr/bin/sh": []byte("sh")})},
+			arch:    "amd64",
+			wantErr: ErrBinaryNotFound,
+		},
+		{
+			name: "binary deleted by whiteout",
+			layers: [][]byte{
+				buildLayer(t, map[string][]byte{"usr/bin/golangci-lint": fakeBinary}),
+				buildLayer(t, map[string][]byte{"usr/bin/.wh.golangci-lint": nil}),
+			},
+			arch:    "amd64",
+			wantErr: ErrBinaryNotFound,
+		},
+		{
+			name:    "tampered layer",
+			layers:  [][]byte{buildLayer(t, map[string][]byte{"usr/bin/golangci-lint": fakeBinary})},
+			arch:    "amd64",
+			tamper:  true,
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "no platform variant",
+			layers:  [][]byte{buildLayer(t, map[string][]byte{"usr/bin/golangci-lint": fakeBinary})},
+			arch:    "riscv64",
+			wantErr: ErrAssetNotFound,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // newTestDownloader uses t.Setenv
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestRegistry(t, tt.layers...)
+
+			if tt.tamper {
+				for digest := range registry.blobs {
+					registry.blobs[digest] = append(registry.blobs[digest], 0)
+				}
+			}
+
+			dl := newOCITestDownloader(t, registry)
+			dl.config.Arch = tt.arch
+
+			err := dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if dl.cacheManager.IsCached(testVersion) {
+				t.Error("version should not be cached after a failed install")
+			}
+		})
+	}
+}
+
+func TestOCISource_Lockfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		frozen  bool
+		strict  bool
+		wantErr error
+	}{
+		{name: "lock ignored with a warning"},
+		{name: "frozen", frozen: true, wantErr: ErrChecksumUnavailable},
+		{name: "strict", strict: true, wantErr: ErrChecksumUnavailable},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // newTestDownloader uses t.Setenv
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestRegistry(t, buildLayer(t, map[string][]byte{"usr/bin/golangci-lint": fakeBinary}))
+
+			dl := newOCITestDownloader(t, registry)
+			dl.lock = &lockfile.Lockfile{Version: testVersion, Checksums: map[string]string{"linux-amd64": strings.Repeat("0", 64)}}
+			dl.frozen = tt.frozen
+			dl.requireChecksum = tt.strict
+
+			err := dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := dl.cacheManager.IsCached(testVersion); got != (tt.wantErr == nil) {
+				t.Errorf("IsCached() = %v, want %v", got, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestImageTagVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "v1.55.2", want: "v1.55.2"},
+		{tag: "1.55.2", want: "v1.55.2"},
+		{tag: "latest", want: ""},
+		{tag: "v1.55.2-alpine", want: ""},
+		{tag: "v1.55", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+
+			got := imageTagVersion(tt.tag)
+			if got != tt.want {
+				t.Errorf("imageTagVersion(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+
+			// Tags round-trip through the docker-image detection pattern
+			if got != "" {
+				detected := detector.GetDockerImagePattern().ExtractVersion("image: golangci/golangci-lint:" + tt.tag)
+				if detected != got {
+					t.Errorf("docker-image pattern detected %q, want %q", detected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	t.Parallel()
+
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull,push"`)
+
+	if scheme != "Bearer" {
+		t.Errorf("scheme = %q, want Bearer", scheme)
+	}
+
+	want := map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:a/b:pull,push",
+	}
+
+	for key, value := range want {
+		if params[key] != value {
+			t.Errorf("params[%s] = %q, want %q", key, params[key], value)
+		}
+	}
+}
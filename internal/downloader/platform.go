@@ -0,0 +1,109 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// publishedPlatforms returns the platforms golangci-lint publishes release archives for,
+// in asset naming (armv6/armv7 for 32-bit ARM). Each release may only cover a subset of them,
+// as listed by its checksums manifest.
+func publishedPlatforms() []string {
+	return []string{
+		"darwin-amd64", "darwin-arm64",
+		"freebsd-386", "freebsd-amd64", "freebsd-arm64", "freebsd-armv6", "freebsd-armv7",
+		"illumos-amd64",
+		"linux-386", "linux-amd64", "linux-arm64", "linux-armv6", "linux-armv7", "linux-loong64",
+		"linux-mips64", "linux-mips64le", "linux-ppc64le", "linux-riscv64", "linux-s390x",
+		"netbsd-386", "netbsd-amd64", "netbsd-arm64", "netbsd-armv6", "netbsd-armv7",
+		"windows-386", "windows-amd64", "windows-arm64", "windows-armv6", "windows-armv7",
+	}
+}
+
+// releasePlatforms returns the platforms a release publishes archives for, read from the
+// archive names of its checksums manifest, e.g. golangci-lint-1.55.2-linux-armv7.tar.gz.
+func (d *Downloader) releasePlatforms(ctx context.Context, mirror Mirror, version, goarch string) ([]string, error) {
+	manifestURL := mirror.ManifestURL(version, d.config.OS, goarch)
+	if manifestURL == "" {
+		return nil, fmt.Errorf("%w: %s has no checksums manifest", ErrChecksumUnavailable, mirror.BaseURL)
+	}
+
+	manifest, err := d.fetchChecksumFileOnce(ctx, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := "golangci-lint-" + strings.TrimPrefix(version, "v") + "-"
+
+	var platforms []string
+
+	for line := range strings.Lines(string(manifest)) {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		name, ok := strings.CutPrefix(strings.TrimPrefix(fields[1], "*"), prefix)
+		if !ok {
+			continue
+		}
+
+		for _, ext := range []string{"." + tarGzExtension, "." + zipExtension} {
+			if platform, ok := strings.CutSuffix(name, ext); ok {
+				platforms = append(platforms, platform)
+			}
+		}
+	}
+
+	slices.Sort(platforms)
+
+	return platforms, nil
+}
+
+// missingAssetError explains why no mirror served the archive of a version when the release
+// doesn't publish one for the platform, according to its checksums manifest or, without one,
+// to the platforms golangci-lint publishes. It returns nil when the archive should exist.
+func (d *Downloader) missingAssetError(ctx context.Context, mirrors []Mirror, version, goarch string) error {
+	platform := d.config.OS + "-" + goarch
+
+	for _, mirror := range mirrors {
+		if mirror.archiveURL != "" {
+			continue
+		}
+
+		platforms, err := d.releasePlatforms(ctx, mirror, version, goarch)
+		if err != nil || len(platforms) == 0 {
+			continue
+		}
+
+		if slices.Contains(platforms, platform) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: no asset for %s in version %s (available: %s)",
+			ErrAssetNotFound, platform, version, strings.Join(platforms, ", "))
+	}
+
+	if !slices.Contains(publishedPlatforms(), platform) {
+		return fmt.Errorf("%w: no asset for %s in version %s, golangci-lint doesn't publish this platform",
+			ErrAssetNotFound, platform, version)
+	}
+
+	return nil
+}
+
+// allNotFound reports whether every mirror answered 404.
+func allNotFound(errs []error) bool {
+	for _, err := range errs {
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+			return false
+		}
+	}
+
+	return len(errs) > 0
+}
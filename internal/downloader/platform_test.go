@@ -0,0 +1,88 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDownload_ARMVariant(t *testing.T) {
+	archive := buildTarballFor(t, testVersion, "linux-armv7", fakeExecutable(t, "linux", "arm"))
+	assetPath := "/" + testVersion + "/golangci-lint-1.55.2-linux-armv7.tar.gz"
+
+	server := newReleaseServer(t, map[string][]byte{
+		assetPath:                 archive,
+		manifestPath(testVersion): []byte(sha256Hex(archive) + "  golangci-lint-1.55.2-linux-armv7.tar.gz\n"),
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors, Platform: "linux/arm/v7", RequireChecksum: true})
+
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	if !dl.cacheManager.IsCached(testVersion) {
+		t.Error("version should be cached after download")
+	}
+}
+
+func TestDownload_MissingPlatform(t *testing.T) {
+	manifest := strings.Repeat("0", sha256HexLength) + "  golangci-lint-1.55.2-linux-amd64.tar.gz\n" +
+		strings.Repeat("1", sha256HexLength) + "  golangci-lint-1.55.2-linux-armv6.tar.gz\n" +
+		strings.Repeat("2", sha256HexLength) + "  golangci-lint-1.55.2-windows-amd64.zip\n"
+
+	tests := []struct {
+		name        string
+		platform    string
+		files       map[string][]byte
+		wantErr     error
+		wantMessage string
+	}{
+		{
+			name:        "not in the release manifest",
+			platform:    "linux/arm/v7",
+			files:       map[string][]byte{manifestPath(testVersion): []byte(manifest)},
+			wantErr:     ErrAssetNotFound,
+			wantMessage: "no asset for linux-armv7 in version v1.55.2 (available: linux-amd64, linux-armv6, windows-amd64)",
+		},
+		{
+			name:        "never published",
+			platform:    "plan9/amd64",
+			files:       map[string][]byte{},
+			wantErr:     ErrAssetNotFound,
+			wantMessage: "no asset for plan9-amd64 in version v1.55.2",
+		},
+		{
+			name:     "published platform, release missing from the mirror",
+			platform: "linux/386",
+			files:    map[string][]byte{},
+			wantErr:  ErrNoMirrors,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // newTestDownloader uses t.Setenv
+		t.Run(tt.name, func(t *testing.T) {
+			mirrors, err := ParseMirrors([]string{newReleaseServer(t, tt.files).URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{Mirrors: mirrors, Platform: tt.platform, Retry: fastRetry(1)})
+
+			err = dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("Download() error = %q, want it to contain %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
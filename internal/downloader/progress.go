@@ -0,0 +1,98 @@
+package downloader
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	progressInterval = 200 * time.Millisecond
+	bytesPerMB       = 1024 * 1024
+)
+
+// progressWriter reports download progress (bytes, total, throughput and ETA) on a terminal.
+type progressWriter struct {
+	out         io.Writer
+	total       int64 // -1 when unknown
+	written     int64 // includes resumed bytes
+	startOffset int64
+	start       time.Time
+	lastDraw    time.Time
+	now         func() time.Time
+}
+
+// newProgressWriter creates a progress writer starting at offset (resumed bytes) out of total.
+func newProgressWriter(out io.Writer, offset, total int64) *progressWriter {
+	now := time.Now()
+
+	return &progressWriter{
+		out:         out,
+		total:       total,
+		written:     offset,
+		startOffset: offset,
+		start:       now,
+		now:         time.Now,
+	}
+}
+
+// Write implements io.Writer, counting bytes and redrawing at most every progressInterval.
+func (p *progressWriter) Write(data []byte) (int, error) {
+	p.written += int64(len(data))
+
+	if now := p.now(); now.Sub(p.lastDraw) >= progressInterval {
+		p.lastDraw = now
+		p.draw(now)
+	}
+
+	return len(data), nil
+}
+
+// Finish draws the final state and ends the progress line.
+func (p *progressWriter) Finish() {
+	p.draw(p.now())
+	_, _ = fmt.Fprintln(p.out)
+}
+
+func (p *progressWriter) draw(now time.Time) {
+	elapsed := now.Sub(p.start).Seconds()
+
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(p.written-p.startOffset) / elapsed
+	}
+
+	// \r returns to the line start, \033[K clears leftovers from a longer previous line.
+	_, _ = fmt.Fprintf(p.out, "\r\033[K%s", formatProgress(p.written, p.total, rate))
+}
+
+// formatProgress renders a progress line such as "12.0 MB / 40.0 MB (30%)  4.0 MB/s  ETA 7s".
+func formatProgress(written, total int64, rate float64) string {
+	line := formatMB(written)
+
+	if total > 0 {
+		line += fmt.Sprintf(" / %s (%d%%)", formatMB(total), written*100/total)
+	}
+
+	line += fmt.Sprintf("  %s/s", formatMB(int64(rate)))
+
+	if total > 0 && rate > 0 && written < total {
+		eta := time.Duration(float64(total-written)/rate) * time.Second
+		line += "  ETA " + eta.Round(time.Second).String()
+	}
+
+	return line
+}
+
+func formatMB(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/bytesPerMB)
+}
+
+// isTerminal reports whether the file is a terminal. Other character devices, such as /dev/null,
+// are not. Progress is disabled otherwise so that redirected or eval'd output stays clean.
+func isTerminal(file *os.File) bool {
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
@@ -0,0 +1,123 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		written int64
+		total   int64
+		rate    float64
+		want    string
+	}{
+		{
+			name:    "known total",
+			written: 10 * bytesPerMB,
+			total:   40 * bytesPerMB,
+			rate:    5 * bytesPerMB,
+			want:    "10.0 MB / 40.0 MB (25%)  5.0 MB/s  ETA 6s",
+		},
+		{
+			name:    "unknown total",
+			written: 3 * bytesPerMB,
+			total:   -1,
+			rate:    bytesPerMB,
+			want:    "3.0 MB  1.0 MB/s",
+		},
+		{
+			name:    "complete",
+			written: 40 * bytesPerMB,
+			total:   40 * bytesPerMB,
+			rate:    5 * bytesPerMB,
+			want:    "40.0 MB / 40.0 MB (100%)  5.0 MB/s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := formatProgress(test.written, test.total, test.rate); got != test.want {
+				t.Errorf("formatProgress() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProgressWriter_Throttle(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	clock := time.Unix(0, 0)
+
+	progress := newProgressWriter(&out, 0, 100)
+	progress.start = clock
+	progress.lastDraw = clock
+	progress.now = func() time.Time { return clock }
+
+	_, _ = progress.Write(make([]byte, 10))
+
+	if out.Len() != 0 {
+		t.Errorf("progress drawn before interval elapsed: %q", out.String())
+	}
+
+	clock = clock.Add(time.Second)
+
+	_, _ = progress.Write(make([]byte, 10))
+
+	if !strings.Contains(out.String(), "(20%)") {
+		t.Errorf("progress output = %q, want 20%%", out.String())
+	}
+}
+
+func TestIsTerminal_NullDevice(t *testing.T) {
+	t.Parallel()
+
+	// /dev/null is a character device, but not a terminal
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+
+	defer func() { _ = null.Close() }()
+
+	if isTerminal(null) {
+		t.Errorf("isTerminal(%s) = true, want false", os.DevNull)
+	}
+}
+
+func TestDownloadFile_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("x"), 2*bytesPerMB)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+
+	var out bytes.Buffer
+
+	dl := &Downloader{httpClient: server.Client(), progress: &out}
+
+	if err := dl.downloadFile(context.Background(), server.URL, filepath.Join(t.TempDir(), "archive")); err != nil {
+		t.Fatalf("downloadFile() failed: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "2.0 MB / 2.0 MB (100%)") {
+		t.Errorf("progress output = %q, want final 100%% line", out.String())
+	}
+}
@@ -0,0 +1,85 @@
+package downloader
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// GitHubTokenEnvVar and GHTokenEnvVar hold the token used to authenticate GitHub API calls.
+	GitHubTokenEnvVar = "GITHUB_TOKEN"
+	GHTokenEnvVar     = "GH_TOKEN"
+
+	githubAPIHost = "api.github.com"
+)
+
+// RateLimitError is returned when the GitHub API rate limit is exhausted.
+type RateLimitError struct {
+	// Limit is the number of requests allowed per window.
+	Limit int
+	// Reset is when the rate limit window resets, zero when unknown.
+	Reset time.Time
+	// Authenticated tells whether the request was sent with a token.
+	Authenticated bool
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	msg := "GitHub API rate limit exceeded"
+	if e.Limit > 0 {
+		msg += fmt.Sprintf(" (%d requests/hour)", e.Limit)
+	}
+
+	if !e.Reset.IsZero() {
+		wait := max(time.Until(e.Reset), 0).Round(time.Minute)
+		msg += fmt.Sprintf(", resets at %s (in %s)", e.Reset.Local().Format(time.Kitchen), wait)
+	}
+
+	if !e.Authenticated {
+		msg += fmt.Sprintf("; set %s or %s to raise the limit", GitHubTokenEnvVar, GHTokenEnvVar)
+	}
+
+	return msg
+}
+
+// Unwrap makes RateLimitError match ErrRateLimited.
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// authorize adds the GitHub token to requests sent to the GitHub API.
+// The token is never sent to mirrors.
+func (d *Downloader) authorize(req *http.Request) {
+	if d.githubToken == "" || req.URL.Host != githubAPIHost {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+d.githubToken)
+}
+
+// rateLimitError returns a RateLimitError when a response reports an exhausted rate limit, nil otherwise.
+func rateLimitError(resp *http.Response) *RateLimitError {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return nil
+	}
+
+	rateLimit := &RateLimitError{
+		Authenticated: resp.Request != nil && resp.Request.Header.Get("Authorization") != "",
+	}
+
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		rateLimit.Limit = limit
+	}
+
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+
+	return rateLimit
+}
@@ -0,0 +1,167 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchAvailableVersions_RateLimited(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+
+	var requests int
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"API rate limit exceeded for 203.0.113.7."}`))
+	}))
+	t.Cleanup(api.Close)
+
+	dl := newTestDownloader(t, Options{
+		Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}},
+		Retry:   fastRetry(3),
+	})
+
+	_, err := dl.FetchAvailableVersions(context.Background(), ReleaseFilter{Limit: 10})
+
+	var rateLimit *RateLimitError
+	if !errors.As(err, &rateLimit) {
+		t.Fatalf("FetchAvailableVersions() error = %v, want a RateLimitError", err)
+	}
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("FetchAvailableVersions() error = %v, want %v", err, ErrRateLimited)
+	}
+
+	if !rateLimit.Reset.Equal(reset) || rateLimit.Limit != 60 || rateLimit.Authenticated {
+		t.Errorf("RateLimitError = %+v, want reset %s, limit 60, unauthenticated", rateLimit, reset)
+	}
+
+	if !strings.Contains(err.Error(), GitHubTokenEnvVar) {
+		t.Errorf("error %q should tell how to pass a token", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("API hit %d times, rate limited requests should not be retried", requests)
+	}
+}
+
+func TestFetchAvailableVersions_APIErrorMessage(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Bad credentials","documentation_url":"https://docs.github.com/rest"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	dl := newTestDownloader(t, Options{Mirrors: []Mirror{{BaseURL: api.URL, APIURL: api.URL}}})
+
+	_, err := dl.FetchAvailableVersions(context.Background(), ReleaseFilter{Limit: 10})
+	if !errors.Is(err, ErrGitHubAPI) {
+		t.Fatalf("FetchAvailableVersions() error = %v, want %v", err, ErrGitHubAPI)
+	}
+
+	if !strings.HasSuffix(err.Error(), "status 401: Bad credentials") {
+		t.Errorf("FetchAvailableVersions() error = %q, want the API message instead of the raw body", err)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		headers   map[string]string
+		authToken string
+		want      *RateLimitError
+	}{
+		{
+			name:    "exhausted",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Limit": "60", "X-RateLimit-Reset": "1700000000"},
+			want:    &RateLimitError{Limit: 60, Reset: time.Unix(1700000000, 0)},
+		},
+		{
+			name:      "exhausted with token",
+			status:    http.StatusTooManyRequests,
+			headers:   map[string]string{"X-RateLimit-Remaining": "0"},
+			authToken: "secret",
+			want:      &RateLimitError{Authenticated: true},
+		},
+		{
+			name:    "forbidden with requests remaining",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "42"},
+		},
+		{
+			name:    "success",
+			status:  http.StatusOK,
+			headers: map[string]string{"X-RateLimit-Remaining": "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "https://api.github.com/", http.NoBody)
+			if tt.authToken != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.authToken)
+			}
+
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}, Request: req}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+
+			got := rateLimitError(resp)
+
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("rateLimitError() = %+v, want nil", got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("rateLimitError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token string
+		url   string
+		want  string
+	}{
+		{name: "GitHub API", token: "secret", url: DefaultSource().Name(), want: "Bearer secret"},
+		{name: "mirror API", token: "secret", url: "https://artifactory.example.com/api/releases"},
+		{name: "no token", url: DefaultSource().Name()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dl := &Downloader{githubToken: tt.token}
+			req := httptest.NewRequest(http.MethodGet, tt.url, http.NoBody)
+
+			dl.authorize(req)
+
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
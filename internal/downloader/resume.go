@@ -0,0 +1,68 @@
+package downloader
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	partialSuffix   = ".partial"
+	validatorSuffix = ".validator"
+)
+
+// fileSize returns the size of a file, or 0 if it can't be read.
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return 0
+	}
+
+	return info.Size()
+}
+
+// readValidator returns the If-Range validator stored next to a partial download.
+func readValidator(path string) string {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is internally controlled
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// writeValidator stores the validator used to resume a partial download.
+// Strong ETags are preferred; Last-Modified is used otherwise.
+// Weak ETags can't be used with If-Range, so nothing is stored for them.
+func writeValidator(path string, header http.Header) {
+	validator := header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = header.Get("Last-Modified")
+	}
+
+	if validator == "" {
+		_ = os.Remove(path)
+
+		return
+	}
+
+	_ = os.WriteFile(path, []byte(validator), filePermission)
+}
+
+// rangeStartsAt reports whether a Content-Range header ("bytes 100-199/200") starts at offset.
+func rangeStartsAt(contentRange string, offset int64) bool {
+	spec, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return false
+	}
+
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return false
+	}
+
+	value, err := strconv.ParseInt(start, 10, 64)
+
+	return err == nil && value == offset
+}
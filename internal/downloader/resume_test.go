@@ -0,0 +1,144 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDownloadFile_Resume(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("golangci-lint"), 1024)
+	half := int64(len(content) / 2)
+
+	var gotRange string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+
+		w.Header().Set("ETag", `"abc"`)
+		http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+
+	if err := os.WriteFile(dest+partialSuffix, content[:half], filePermission); err != nil {
+		t.Fatalf("failed to seed partial file: %v", err)
+	}
+
+	if err := os.WriteFile(dest+partialSuffix+validatorSuffix, []byte(`"abc"`), filePermission); err != nil {
+		t.Fatalf("failed to seed validator: %v", err)
+	}
+
+	dl := &Downloader{httpClient: server.Client()}
+
+	if err := dl.downloadFile(context.Background(), server.URL, dest); err != nil {
+		t.Fatalf("downloadFile() failed: %v", err)
+	}
+
+	if gotRange != "bytes=6656-" {
+		t.Errorf("Range header = %q, want %q", gotRange, "bytes=6656-")
+	}
+
+	assertFileContent(t, dest, content)
+
+	if _, err := os.Stat(dest + partialSuffix); !os.IsNotExist(err) {
+		t.Error("partial file should be removed after completion")
+	}
+}
+
+func TestDownloadFile_ResumeValidatorChanged(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("new"), 1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"new"`)
+		http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+
+	if err := os.WriteFile(dest+partialSuffix, []byte("old partial content"), filePermission); err != nil {
+		t.Fatalf("failed to seed partial file: %v", err)
+	}
+
+	if err := os.WriteFile(dest+partialSuffix+validatorSuffix, []byte(`"old"`), filePermission); err != nil {
+		t.Fatalf("failed to seed validator: %v", err)
+	}
+
+	dl := &Downloader{httpClient: server.Client()}
+
+	if err := dl.downloadFile(context.Background(), server.URL, dest); err != nil {
+		t.Fatalf("downloadFile() failed: %v", err)
+	}
+
+	assertFileContent(t, dest, content)
+}
+
+func TestDownloadFile_NoRangeSupport(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("full archive content")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+
+	if err := os.WriteFile(dest+partialSuffix, []byte("garbage"), filePermission); err != nil {
+		t.Fatalf("failed to seed partial file: %v", err)
+	}
+
+	dl := &Downloader{httpClient: server.Client()}
+
+	if err := dl.downloadFile(context.Background(), server.URL, dest); err != nil {
+		t.Fatalf("downloadFile() failed: %v", err)
+	}
+
+	assertFileContent(t, dest, content)
+}
+
+func TestRangeStartsAt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header string
+		offset int64
+		want   bool
+	}{
+		{header: "bytes 100-199/200", offset: 100, want: true},
+		{header: "bytes 0-199/200", offset: 100, want: false},
+		{header: "bytes */200", offset: 100, want: false},
+		{header: "", offset: 100, want: false},
+	}
+
+	for _, test := range tests {
+		if got := rangeStartsAt(test.header, test.offset); got != test.want {
+			t.Errorf("rangeStartsAt(%q, %d) = %v, want %v", test.header, test.offset, got, test.want)
+		}
+	}
+}
+
+func assertFileContent(t *testing.T, path string, want []byte) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s has %d bytes, want %d", path, len(got), len(want))
+	}
+}
@@ -0,0 +1,151 @@
+package downloader
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// RetriesEnvVar is the environment variable holding the number of attempts for each HTTP request.
+	RetriesEnvVar = "GLINT_VM_RETRIES"
+	// TimeoutEnvVar is the environment variable holding the overall time limit of a command, retries included.
+	TimeoutEnvVar = "GLINT_VM_TIMEOUT"
+
+	// DefaultRetryAttempts is the default number of attempts for each HTTP request.
+	DefaultRetryAttempts = 3
+
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryPolicy configures retries of idempotent HTTP requests.
+type RetryPolicy struct {
+	// Attempts is the total number of attempts, including the first one.
+	Attempts int
+	// BaseDelay is the delay before the first retry, doubled on each subsequent retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay, including delays requested through Retry-After.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:  DefaultRetryAttempts,
+		BaseDelay: defaultRetryBaseDelay,
+		MaxDelay:  defaultRetryMaxDelay,
+	}
+}
+
+// withDefaults fills unset fields with default values.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	defaults := DefaultRetryPolicy()
+
+	if p.Attempts < 1 {
+		p.Attempts = defaults.Attempts
+	}
+
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaults.BaseDelay
+	}
+
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaults.MaxDelay
+	}
+
+	return p
+}
+
+// do runs fn until it succeeds, fails with a non-transient error, the context is
+// cancelled or all attempts are used. Partial downloads make retried transfers resume.
+func (p RetryPolicy) do(ctx context.Context, fn func() error) error {
+	var err error
+
+	for attempt := 1; attempt <= p.Attempts; attempt++ {
+		err = fn()
+		if err == nil || ctx.Err() != nil || !isTransient(err) {
+			break
+		}
+
+		if attempt == p.Attempts {
+			return fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+		}
+
+		delay := p.delay(attempt, err)
+
+		fmt.Fprintf(os.Stderr, "Warning: %v, retrying in %s (attempt %d/%d)\n", err, delay.Round(time.Millisecond), attempt+1, p.Attempts)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("%w (cancelled after %d attempts)", err, attempt)
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// delay returns the wait before the next attempt: the server's Retry-After when given,
+// otherwise an exponential backoff with jitter.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, p.MaxDelay)
+	}
+
+	backoff := min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+
+	// Wait between half and the full backoff so concurrent clients don't retry in lockstep
+	return backoff/2 + rand.N(backoff/2+1) //nolint:gosec // Jitter doesn't need a secure random source
+}
+
+// isTransient reports whether a request error is worth retrying.
+func isTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// Untrusted certificates won't become trusted by retrying
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+
+	// Connection resets, timeouts and truncated bodies
+	return errors.Is(err, errRequestFailed) || errors.Is(err, errTransferFailed)
+}
+
+// parseRetryAfter parses a Retry-After header, given in seconds or as an HTTP date.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
@@ -0,0 +1,303 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry retries without noticeable delays in tests.
+func fastRetry(attempts int) RetryPolicy {
+	return RetryPolicy{Attempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantCalls    int
+		wantErr      error
+		wantAttempts string
+	}{
+		{
+			name:      "success on first attempt",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "transient error then success",
+			errs:      []error{&StatusError{StatusCode: http.StatusServiceUnavailable}, errRequestFailed, nil},
+			wantCalls: 3,
+		},
+		{
+			name:         "gives up after all attempts",
+			errs:         []error{errTransferFailed, errTransferFailed, errTransferFailed},
+			wantCalls:    3,
+			wantErr:      errTransferFailed,
+			wantAttempts: "gave up after 3 attempts",
+		},
+		{
+			name:      "not found is not retried",
+			errs:      []error{&StatusError{StatusCode: http.StatusNotFound}},
+			wantCalls: 1,
+			wantErr:   ErrHTTPRequest,
+		},
+		{
+			name:      "non-transport error is not retried",
+			errs:      []error{ErrChecksumMismatch},
+			wantCalls: 1,
+			wantErr:   ErrChecksumMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+
+			err := fastRetry(3).do(context.Background(), func() error {
+				err := tt.errs[calls]
+				calls++
+
+				return err
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("do() made %d calls, want %d", calls, tt.wantCalls)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("do() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantAttempts != "" && (err == nil || !strings.Contains(err.Error(), tt.wantAttempts)) {
+				t.Errorf("do() error = %v, want it to mention %q", err, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_DoCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	policy := RetryPolicy{Attempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	calls := 0
+
+	err := policy.do(ctx, func() error {
+		calls++
+
+		cancel()
+
+		return errRequestFailed
+	})
+
+	if calls != 1 {
+		t.Errorf("do() made %d calls after cancellation, want 1", calls)
+	}
+
+	if !errors.Is(err, errRequestFailed) {
+		t.Errorf("do() error = %v, want %v", err, errRequestFailed)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{Attempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "first backoff", attempt: 1, err: errRequestFailed, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "third backoff", attempt: 3, err: errRequestFailed, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped backoff", attempt: 10, err: errRequestFailed, min: 500 * time.Millisecond, max: time.Second},
+		{
+			name:    "retry-after",
+			attempt: 1,
+			err:     &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 700 * time.Millisecond},
+			min:     700 * time.Millisecond,
+			max:     700 * time.Millisecond,
+		},
+		{
+			name:    "retry-after capped",
+			attempt: 1,
+			err:     &StatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Hour},
+			min:     time.Second,
+			max:     time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := policy.delay(tt.attempt, tt.err)
+			if got < tt.min || got > tt.max {
+				t.Errorf("delay(%d) = %s, want between %s and %s", tt.attempt, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{name: "empty", header: "", want: 0},
+		{name: "seconds", header: "120", want: 2 * time.Minute},
+		{name: "http date", header: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second},
+		{name: "date in the past", header: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "negative", header: "-5", want: 0},
+		{name: "garbage", header: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseRetryAfter(tt.header, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadFromMirrors_RetriesTransientStatus(t *testing.T) {
+	content := []byte("archive content")
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	dl := newTestDownloader(t, Options{
+		Mirrors: []Mirror{{BaseURL: server.URL, ArchiveTemplate: "archive.tar.gz"}},
+		Retry:   fastRetry(3),
+	})
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+
+	if _, err := dl.downloadFromMirrors(context.Background(), testVersion, dl.config.AssetArch(), dest); err != nil {
+		t.Fatalf("downloadFromMirrors() failed: %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+
+	assertFileContent(t, dest, content)
+}
+
+func TestDownloadFromMirrors_GivesUp(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	dl := newTestDownloader(t, Options{
+		Mirrors: []Mirror{{BaseURL: server.URL, ArchiveTemplate: "archive.tar.gz"}},
+		Retry:   fastRetry(3),
+	})
+
+	_, err := dl.downloadFromMirrors(context.Background(), testVersion, dl.config.AssetArch(), filepath.Join(t.TempDir(), "archive.tar.gz"))
+	if !errors.Is(err, ErrNoMirrors) {
+		t.Fatalf("downloadFromMirrors() error = %v, want %v", err, ErrNoMirrors)
+	}
+
+	if !strings.Contains(err.Error(), "gave up after 3 attempts") {
+		t.Errorf("downloadFromMirrors() error = %v, want it to mention the attempt count", err)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+}
+
+func TestDownloadFromMirrors_ResumesInterruptedTransfer(t *testing.T) {
+	content := bytes.Repeat([]byte("golangci-lint"), 1024)
+	half := len(content) / 2
+
+	var (
+		requests  atomic.Int32
+		lastRange atomic.Value
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRange.Store(r.Header.Get("Range"))
+
+		w.Header().Set("ETag", `"abc"`)
+
+		if requests.Add(1) == 1 {
+			// Drop the connection halfway through the body
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:half])
+			w.(http.Flusher).Flush()
+
+			panic(http.ErrAbortHandler)
+		}
+
+		http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+
+	dl := newTestDownloader(t, Options{
+		Mirrors: []Mirror{{BaseURL: server.URL, ArchiveTemplate: "archive.tar.gz"}},
+		Retry:   fastRetry(3),
+	})
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+
+	if _, err := dl.downloadFromMirrors(context.Background(), testVersion, dl.config.AssetArch(), dest); err != nil {
+		t.Fatalf("downloadFromMirrors() failed: %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+
+	if got, want := lastRange.Load(), "bytes="+strconv.Itoa(half)+"-"; got != want {
+		t.Errorf("retry Range header = %q, want %q", got, want)
+	}
+
+	assertFileContent(t, dest, content)
+
+	if _, err := os.Stat(dest + partialSuffix); !os.IsNotExist(err) {
+		t.Error("partial file should be removed after completion")
+	}
+}
@@ -0,0 +1,19 @@
+package downloader
+
+const (
+	// RosettaEnvVar is the environment variable enabling the darwin-amd64 fallback on Apple Silicon.
+	RosettaEnvVar = "GLINT_VM_ROSETTA"
+
+	// emulatedArch is the architecture installed on Apple Silicon when a release has no darwin-arm64 archive.
+	emulatedArch = "amd64"
+)
+
+// emulatedPlatform returns the platform installed instead of darwin-arm64 when a release has
+// no native archive, if the Rosetta fallback is enabled.
+func (d *Downloader) emulatedPlatform() (string, bool) {
+	if !d.rosettaFallback || d.config.OS != "darwin" || d.config.AssetArch() != "arm64" {
+		return "", false
+	}
+
+	return d.config.OS + "-" + emulatedArch, true
+}
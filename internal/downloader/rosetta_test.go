@@ -0,0 +1,119 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/lockfile"
+)
+
+func TestDownload_RosettaFallback(t *testing.T) {
+	archive := buildTarballFor(t, testVersion, "darwin-amd64", fakeExecutable(t, "darwin", "amd64"))
+	assetPath := "/" + testVersion + "/golangci-lint-1.55.2-darwin-amd64.tar.gz"
+
+	files := map[string][]byte{
+		assetPath:                 archive,
+		manifestPath(testVersion): []byte(sha256Hex(archive) + "  golangci-lint-1.55.2-darwin-amd64.tar.gz\n"),
+	}
+
+	tests := []struct {
+		name         string
+		fallback     bool
+		wantErr      error
+		wantEmulated bool
+	}{
+		{name: "fallback enabled", fallback: true, wantEmulated: true},
+		{name: "fallback disabled", wantErr: ErrAssetNotFound},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // newTestDownloader uses t.Setenv
+		t.Run(tt.name, func(t *testing.T) {
+			mirrors, err := ParseMirrors([]string{newReleaseServer(t, files).URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{
+				Mirrors:         mirrors,
+				Platform:        "darwin/arm64",
+				RosettaFallback: tt.fallback,
+				RequireChecksum: true,
+			})
+
+			err = dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+
+			info := dl.config.GetVersionInfo(testVersion)
+			if info.Emulated != tt.wantEmulated {
+				t.Errorf("GetVersionInfo().Emulated = %v, want %v", info.Emulated, tt.wantEmulated)
+			}
+
+			if tt.wantEmulated && info.Platform != "darwin-amd64" {
+				t.Errorf("GetVersionInfo().Platform = %q, want darwin-amd64", info.Platform)
+			}
+		})
+	}
+}
+
+func TestDownload_RosettaLockfile(t *testing.T) {
+	amd64Archive := buildTarballFor(t, testVersion, "darwin-amd64", fakeExecutable(t, "darwin", "amd64"))
+	arm64Archive := buildTarballFor(t, testVersion, "darwin-arm64", fakeExecutable(t, "darwin", "arm64"))
+
+	// The lockfile predates the darwin-arm64 archive
+	lock := &lockfile.Lockfile{Version: testVersion, Checksums: map[string]string{"darwin-amd64": sha256Hex(amd64Archive)}}
+
+	tests := []struct {
+		name         string
+		native       bool
+		frozen       bool
+		wantErr      error
+		wantEmulated bool
+	}{
+		{name: "fallback checked against the emulated checksum", frozen: true, wantEmulated: true},
+		{name: "unpinned native archive in frozen mode", native: true, frozen: true, wantErr: ErrLockStale},
+		{name: "unpinned native archive checked against the mirror", native: true},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest // newTestDownloader uses t.Setenv
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := sha256Hex(amd64Archive) + "  golangci-lint-1.55.2-darwin-amd64.tar.gz\n"
+			files := map[string][]byte{"/" + testVersion + "/golangci-lint-1.55.2-darwin-amd64.tar.gz": amd64Archive}
+
+			if tt.native {
+				manifest += sha256Hex(arm64Archive) + "  golangci-lint-1.55.2-darwin-arm64.tar.gz\n"
+				files["/"+testVersion+"/golangci-lint-1.55.2-darwin-arm64.tar.gz"] = arm64Archive
+			}
+
+			files[manifestPath(testVersion)] = []byte(manifest)
+
+			mirrors, err := ParseMirrors([]string{newReleaseServer(t, files).URL})
+			if err != nil {
+				t.Fatalf("ParseMirrors() failed: %v", err)
+			}
+
+			dl := newTestDownloader(t, Options{
+				Mirrors:         mirrors,
+				Platform:        "darwin/arm64",
+				RosettaFallback: true,
+				Lock:            lock,
+				Frozen:          tt.frozen,
+			})
+
+			err = dl.Download(context.Background(), testVersion)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := dl.cacheManager.IsCached(testVersion); got != (tt.wantErr == nil) {
+				t.Errorf("IsCached() = %v, want %v", got, tt.wantErr == nil)
+			}
+
+			if info := dl.config.GetVersionInfo(testVersion); info.Emulated != tt.wantEmulated {
+				t.Errorf("GetVersionInfo().Emulated = %v, want %v", info.Emulated, tt.wantEmulated)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// SourceEnvVar is the environment variable selecting the release source kind.
+	SourceEnvVar = "GLINT_VM_SOURCE"
+	// SourceURLEnvVar is the environment variable holding the release source URL.
+	SourceURLEnvVar = "GLINT_VM_SOURCE_URL"
+	// RepoEnvVar is the environment variable holding the owner/name repository of the release source.
+	RepoEnvVar = "GLINT_VM_REPO"
+	// RegistryUsernameEnvVar is the environment variable holding the OCI registry username.
+	RegistryUsernameEnvVar = "GLINT_VM_REGISTRY_USERNAME"
+	// RegistryPasswordEnvVar is the environment variable holding the OCI registry password or token.
+	RegistryPasswordEnvVar = "GLINT_VM_REGISTRY_PASSWORD"
+
+	// SourceGitHub lists releases from github.com, or GitHub Enterprise when a URL is set.
+	SourceGitHub = "github"
+	// SourceGitea lists releases from a Gitea instance.
+	SourceGitea = "gitea"
+	// SourceIndex lists releases from a static index.json file.
+	SourceIndex = "index"
+	// SourceOCI lists image tags from an OCI registry and extracts the binary from the images.
+	SourceOCI = "oci"
+	// SourceGo lists module versions through the Go module proxy and builds golangci-lint from source.
+	SourceGo = "go"
+
+	// DefaultRepo is the upstream golangci-lint repository.
+	DefaultRepo = "golangci/golangci-lint"
+
+	gitHubURL    = "https://github.com"
+	gitHubAPIURL = "https://api.github.com"
+)
+
+// ReleaseSource lists golangci-lint releases and locates their assets.
+type ReleaseSource interface {
+	// Name identifies the source in messages and in the release index cache.
+	Name() string
+
+	// fetchIndex fetches every release, newest first. A cached index from the same
+	// source may be revalidated instead of being fetched again.
+	fetchIndex(ctx context.Context, d *Downloader, cached *releaseIndex) (*releaseIndex, error)
+
+	// assetMirror returns where the archive of a version is downloaded from for a platform.
+	assetMirror(ctx context.Context, d *Downloader, version, goos, goarch string) (Mirror, error)
+}
+
+// binarySource is implemented by release sources serving the golangci-lint binary
+// itself rather than release archives.
+type binarySource interface {
+	// Name identifies the source in messages.
+	Name() string
+
+	// installBinary writes the binary of a version for the downloader's platform into destDir,
+	// using stagingDir for intermediate files.
+	installBinary(ctx context.Context, d *Downloader, version, stagingDir, destDir string) error
+}
+
+// SourceConfig selects a release source.
+type SourceConfig struct {
+	// Kind is SourceGitHub, SourceGitea, SourceIndex, SourceOCI or SourceGo. Defaults to SourceGitHub.
+	Kind string
+	// URL is the GitHub Enterprise or Gitea base URL, the URL of the static index,
+	// or the OCI registry URL.
+	URL string
+	// Repo is the owner/name repository of GitHub and Gitea sources, the image
+	// repository of OCI sources, or the github.com module of Go sources. Defaults to DefaultRepo.
+	Repo string
+	// Token authenticates GitHub Enterprise and Gitea API calls.
+	// github.com uses the downloader's GitHub token instead.
+	Token string
+	// Username and Password authenticate to OCI registries. Anonymous access is used when empty.
+	Username string
+	Password string
+}
+
+// DefaultSource returns the upstream golangci-lint releases on github.com.
+func DefaultSource() ReleaseSource {
+	return newGitHubSource(gitHubURL, gitHubAPIURL, DefaultRepo, "")
+}
+
+// NewReleaseSource creates the release source described by a configuration.
+func NewReleaseSource(cfg SourceConfig) (ReleaseSource, error) {
+	baseURL := strings.TrimSuffix(cfg.URL, "/")
+
+	if baseURL != "" && !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return nil, fmt.Errorf("%w: %q: URL must be http(s)", ErrInvalidSource, cfg.URL)
+	}
+
+	repo := cfg.Repo
+	if repo == "" {
+		repo = DefaultRepo
+	}
+
+	if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("%w: repository %q must be owner/name", ErrInvalidSource, repo)
+	}
+
+	switch cfg.Kind {
+	case "", SourceGitHub:
+		if baseURL == "" || baseURL == gitHubURL {
+			return newGitHubSource(gitHubURL, gitHubAPIURL, repo, ""), nil
+		}
+
+		// GitHub Enterprise serves its REST API under /api/v3
+		return newGitHubSource(baseURL, baseURL+"/api/v3", repo, cfg.Token), nil
+	case SourceGitea:
+		if baseURL == "" {
+			return nil, fmt.Errorf("%w: the gitea source requires a URL", ErrInvalidSource)
+		}
+
+		return newGiteaSource(baseURL, repo, cfg.Token), nil
+	case SourceIndex:
+		if baseURL == "" {
+			return nil, fmt.Errorf("%w: the index source requires the URL of index.json", ErrInvalidSource)
+		}
+
+		return newStaticSource(cfg.URL), nil
+	case SourceOCI:
+		if baseURL == "" {
+			return nil, fmt.Errorf("%w: the oci source requires the registry URL", ErrInvalidSource)
+		}
+
+		return newOCISource(baseURL, repo, cfg.Username, cfg.Password), nil
+	case SourceGo:
+		// Modules are fetched through GOPROXY, not from a URL
+		if baseURL != "" {
+			return nil, fmt.Errorf("%w: the go source uses GOPROXY, not a URL", ErrInvalidSource)
+		}
+
+		return newGoSource("github.com/" + repo), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q (want %s, %s, %s, %s or %s)",
+			ErrInvalidSource, cfg.Kind, SourceGitHub, SourceGitea, SourceIndex, SourceOCI, SourceGo)
+	}
+}
+
+// releaseSources returns the sources listing releases, in order: mirrors exposing
+// a releases API, or the configured source when no mirror has one.
+func (d *Downloader) releaseSources() []ReleaseSource {
+	sources := make([]ReleaseSource, 0, len(d.mirrors))
+
+	for _, mirror := range d.mirrors {
+		if mirror.APIURL != "" {
+			sources = append(sources, newMirrorSource(mirror))
+		}
+	}
+
+	if len(sources) == 0 {
+		sources = append(sources, d.source)
+	}
+
+	return sources
+}
+
+// assetMirrors returns where the archive of a version is downloaded from for a platform:
+// the configured mirrors, or the release source when there are none.
+func (d *Downloader) assetMirrors(ctx context.Context, version, goos, goarch string) ([]Mirror, error) {
+	if len(d.mirrors) > 0 {
+		return d.mirrors, nil
+	}
+
+	mirror, err := d.source.assetMirror(ctx, d, version, goos, goarch)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Mirror{mirror}, nil
+}
+
+// fetchReleaseIndex fetches the release index from the first release source that answers.
+func (d *Downloader) fetchReleaseIndex(ctx context.Context, cached *releaseIndex) (*releaseIndex, error) {
+	var errs []error
+
+	for _, source := range d.releaseSources() {
+		index, err := source.fetchIndex(ctx, d, cached)
+		if err == nil {
+			return index, nil
+		}
+
+		if !shouldFallback(ctx, err) {
+			return nil, err
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: releases API %s failed: %v\n", source.Name(), err)
+
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrNoMirrors, errors.Join(errs...))
+}
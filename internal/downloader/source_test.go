@@ -0,0 +1,226 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewReleaseSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		cfg         SourceConfig
+		wantName    string
+		wantArchive string
+		wantErr     error
+	}{
+		{
+			name:        "default",
+			cfg:         SourceConfig{},
+			wantName:    "https://api.github.com/repos/golangci/golangci-lint/releases",
+			wantArchive: "https://github.com/golangci/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz",
+		},
+		{
+			name:        "github fork",
+			cfg:         SourceConfig{Kind: SourceGitHub, Repo: "acme/golangci-lint"},
+			wantName:    "https://api.github.com/repos/acme/golangci-lint/releases",
+			wantArchive: "https://github.com/acme/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz",
+		},
+		{
+			name:        "github enterprise",
+			cfg:         SourceConfig{Kind: SourceGitHub, URL: "https://ghe.example.com/", Repo: "tools/golangci-lint"},
+			wantName:    "https://ghe.example.com/api/v3/repos/tools/golangci-lint/releases",
+			wantArchive: "https://ghe.example.com/tools/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz",
+		},
+		{
+			name:        "gitea",
+			cfg:         SourceConfig{Kind: SourceGitea, URL: "https://gitea.example.com", Repo: "tools/golangci-lint"},
+			wantName:    "https://gitea.example.com/api/v1/repos/tools/golangci-lint/releases",
+			wantArchive: "https://gitea.example.com/tools/golangci-lint/releases/download/v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz",
+		},
+		{
+			name:     "static index",
+			cfg:      SourceConfig{Kind: SourceIndex, URL: "https://cdn.example.com/golangci/index.json"},
+			wantName: "https://cdn.example.com/golangci/index.json",
+		},
+		{
+			name:     "oci registry",
+			cfg:      SourceConfig{Kind: SourceOCI, URL: "https://registry.example.com/"},
+			wantName: "https://registry.example.com/v2/golangci/golangci-lint",
+		},
+		{
+			name:     "go module",
+			cfg:      SourceConfig{Kind: SourceGo, Repo: "acme/golangci-lint"},
+			wantName: "go:github.com/acme/golangci-lint",
+		},
+		{name: "gitea without URL", cfg: SourceConfig{Kind: SourceGitea}, wantErr: ErrInvalidSource},
+		{name: "oci without URL", cfg: SourceConfig{Kind: SourceOCI}, wantErr: ErrInvalidSource},
+		{name: "index without URL", cfg: SourceConfig{Kind: SourceIndex}, wantErr: ErrInvalidSource},
+		{name: "go with URL", cfg: SourceConfig{Kind: SourceGo, URL: "https://proxy.golang.org"}, wantErr: ErrInvalidSource},
+		{name: "invalid repository", cfg: SourceConfig{Repo: "golangci-lint"}, wantErr: ErrInvalidSource},
+		{name: "invalid URL", cfg: SourceConfig{URL: "ghe.example.com"}, wantErr: ErrInvalidSource},
+		{name: "unknown kind", cfg: SourceConfig{Kind: "gitlab"}, wantErr: ErrInvalidSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			source, err := NewReleaseSource(tt.cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewReleaseSource() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := source.Name(); got != tt.wantName {
+				t.Errorf("Name() = %s, want %s", got, tt.wantName)
+			}
+
+			if tt.wantArchive == "" {
+				return
+			}
+
+			mirror, err := source.assetMirror(context.Background(), nil, testVersion, "linux", "amd64")
+			if err != nil {
+				t.Fatalf("assetMirror() failed: %v", err)
+			}
+
+			if got := mirror.ArchiveURL(testVersion, "linux", "amd64"); got != tt.wantArchive {
+				t.Errorf("ArchiveURL() = %s, want %s", got, tt.wantArchive)
+			}
+		})
+	}
+}
+
+func TestGiteaSource(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+	assetPath := "/tools/golangci-lint/releases/download" + releaseAssetPath(testVersion)
+
+	var gotAuth, gotQuery string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/tools/golangci-lint/releases":
+			gotAuth = r.Header.Get("Authorization")
+			gotQuery = r.URL.RawQuery
+
+			_ = json.NewEncoder(w).Encode([]Release{{TagName: testVersion}, {TagName: "v1.55.1"}})
+		case assetPath:
+			_, _ = w.Write(archive)
+		case assetPath + ".sha256":
+			_, _ = w.Write([]byte(sha256Hex(archive)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	source, err := NewReleaseSource(SourceConfig{Kind: SourceGitea, URL: server.URL, Repo: "tools/golangci-lint", Token: "secret"})
+	if err != nil {
+		t.Fatalf("NewReleaseSource() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Source: source})
+
+	releases, err := dl.FetchAvailableVersions(context.Background(), ReleaseFilter{})
+	if err != nil {
+		t.Fatalf("FetchAvailableVersions() failed: %v", err)
+	}
+
+	if len(releases) != 2 {
+		t.Errorf("FetchAvailableVersions() returned %d releases, want 2", len(releases))
+	}
+
+	if gotAuth != "token secret" || gotQuery != "limit=50" {
+		t.Errorf("releases request: Authorization = %q, query = %q, want token auth and Gitea paging", gotAuth, gotQuery)
+	}
+
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	if !dl.cacheManager.IsCached(testVersion) {
+		t.Error("version should be cached after download")
+	}
+}
+
+func TestStaticSource(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeBinary)
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+
+	index := map[string]any{
+		"releases": []map[string]any{
+			{"version": "1.54.0", "assets": map[string]any{}},
+			{
+				"version": testVersion,
+				"assets": map[string]any{
+					platform: map[string]string{"url": "archives/golangci-lint.tar.gz", "sha256": strings.ToUpper(sha256Hex(archive))},
+				},
+			},
+			{"version": "v1.56.0-rc.1", "prerelease": true},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/golangci/index.json":
+			_ = json.NewEncoder(w).Encode(index)
+		case "/golangci/archives/golangci-lint.tar.gz":
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	source, err := NewReleaseSource(SourceConfig{Kind: SourceIndex, URL: server.URL + "/golangci/index.json"})
+	if err != nil {
+		t.Fatalf("NewReleaseSource() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Source: source})
+
+	releases, err := dl.FetchAvailableVersions(context.Background(), ReleaseFilter{Prerelease: true})
+	if err != nil {
+		t.Fatalf("FetchAvailableVersions() failed: %v", err)
+	}
+
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+
+	if want := []string{"v1.56.0-rc.1", testVersion, "v1.54.0"}; !slices.Equal(tags, want) {
+		t.Errorf("FetchAvailableVersions() = %v, want %v", tags, want)
+	}
+
+	// The archive has no .sha256 file: it must be verified against the index checksum
+	dl.requireChecksum = true
+
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	if !dl.cacheManager.IsCached(testVersion) {
+		t.Error("version should be cached after download")
+	}
+
+	if err := dl.Download(context.Background(), "v1.54.0"); !errors.Is(err, ErrAssetNotFound) {
+		t.Errorf("Download() of a release without asset error = %v, want %v", err, ErrAssetNotFound)
+	}
+
+	if err := dl.Download(context.Background(), "v1.0.0"); !errors.Is(err, ErrReleaseNotFound) {
+		t.Errorf("Download() of an unlisted release error = %v, want %v", err, ErrReleaseNotFound)
+	}
+}
@@ -0,0 +1,222 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+// maxStaticIndexSize bounds the static index read into memory.
+const maxStaticIndexSize = 16 * 1024 * 1024
+
+// staticSource is a release source backed by a static index.json file, e.g.:
+//
+//	{"releases": [{
+//	  "version": "v1.55.2",
+//	  "published_at": "2023-11-03T00:00:00Z",
+//	  "body": "Release notes, in Markdown",
+//	  "assets": {
+//	    "linux-amd64": {"url": "v1.55.2/golangci-lint-1.55.2-linux-amd64.tar.gz", "sha256": "..."}
+//	  }
+//	}]}
+//
+// Asset URLs may be relative to the index URL.
+type staticSource struct {
+	url string
+}
+
+// staticIndex is the document served by a static source.
+type staticIndex struct {
+	Releases []staticRelease `json:"releases"`
+}
+
+type staticRelease struct {
+	Version     string                 `json:"version"`
+	PublishedAt time.Time              `json:"published_at"`
+	Prerelease  bool                   `json:"prerelease"`
+	Body        string                 `json:"body"`
+	Assets      map[string]staticAsset `json:"assets"`
+}
+
+type staticAsset struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// newStaticSource returns the source listing releases from a static index.
+func newStaticSource(indexURL string) *staticSource {
+	return &staticSource{url: indexURL}
+}
+
+// Name implements ReleaseSource.
+func (s *staticSource) Name() string {
+	return s.url
+}
+
+// fetchIndex implements ReleaseSource. A cached index is revalidated with its ETag.
+func (s *staticSource) fetchIndex(ctx context.Context, d *Downloader, cached *releaseIndex) (*releaseIndex, error) {
+	etag := ""
+	if cached != nil && cached.Source == s.url {
+		etag = cached.ETag
+	}
+
+	doc, newETag, err := s.fetch(ctx, d, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc == nil {
+		cached.FetchedAt = time.Now()
+
+		return cached, nil
+	}
+
+	releases := make([]Release, 0, len(doc.Releases))
+
+	for _, release := range doc.Releases {
+		releases = append(releases, Release{
+			TagName:     config.NormalizeVersion(release.Version),
+			Name:        release.Version,
+			PublishedAt: release.PublishedAt,
+			Prerelease:  release.Prerelease,
+			Body:        release.Body,
+		})
+	}
+
+	sortReleasesNewestFirst(releases)
+
+	return &releaseIndex{Source: s.url, ETag: newETag, FetchedAt: time.Now(), Releases: releases}, nil
+}
+
+// assetMirror implements ReleaseSource. The archive checksum listed by the index, if any,
+// is used to verify the download.
+func (s *staticSource) assetMirror(ctx context.Context, d *Downloader, version, goos, goarch string) (Mirror, error) {
+	doc, _, err := s.fetch(ctx, d, "")
+	if err != nil {
+		return Mirror{}, err
+	}
+
+	for _, release := range doc.Releases {
+		if config.NormalizeVersion(release.Version) != version {
+			continue
+		}
+
+		asset, ok := release.Assets[goos+"-"+goarch]
+		if !ok || asset.URL == "" {
+			return Mirror{}, fmt.Errorf("%w: %s has no %s-%s archive in %s", ErrAssetNotFound, version, goos, goarch, s.url)
+		}
+
+		archiveURL, err := s.resolve(asset.URL)
+		if err != nil {
+			return Mirror{}, err
+		}
+
+		return Mirror{
+			BaseURL:          s.url,
+			ChecksumTemplate: defaultChecksumTemplate,
+			archiveURL:       archiveURL,
+			sha256:           strings.ToLower(asset.SHA256),
+		}, nil
+	}
+
+	return Mirror{}, fmt.Errorf("%w: %s is not listed in %s", ErrReleaseNotFound, version, s.url)
+}
+
+// fetch downloads the index, retrying transient failures. It returns a nil index
+// when etag is set and the index didn't change.
+func (s *staticSource) fetch(ctx context.Context, d *Downloader, etag string) (*staticIndex, string, error) {
+	var (
+		doc     *staticIndex
+		newETag string
+	)
+
+	err := d.retry.do(ctx, func() error {
+		var err error
+
+		doc, newETag, err = s.fetchOnce(ctx, d, etag)
+
+		return err
+	})
+
+	return doc, newETag, err
+}
+
+func (s *staticSource) fetchOnce(ctx context.Context, d *Downloader, etag string) (*staticIndex, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, http.NoBody)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	//nolint:gosec // URL comes from the source configuration
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch release index: %w: %w", errRequestFailed, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		return nil, etag, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", newStatusError(s.url, resp)
+	}
+
+	var doc staticIndex
+	if err := json.NewDecoder(http.MaxBytesReader(nil, resp.Body, maxStaticIndexSize)).Decode(&doc); err != nil {
+		return nil, "", fmt.Errorf("failed to decode release index: %w: %w", errTransferFailed, err)
+	}
+
+	return &doc, resp.Header.Get("ETag"), nil
+}
+
+// resolve resolves an asset URL relative to the index URL.
+func (s *staticSource) resolve(assetURL string) (string, error) {
+	base, err := url.Parse(s.url)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidSource, err)
+	}
+
+	ref, err := url.Parse(assetURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: asset URL %q: %w", ErrInvalidSource, assetURL, err)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// sortReleasesNewestFirst sorts releases by descending semantic version.
+// Releases whose tag isn't a version are kept last.
+func sortReleasesNewestFirst(releases []Release) {
+	slices.SortStableFunc(releases, func(a, b Release) int {
+		va, errA := semver.NewVersion(a.TagName)
+		vb, errB := semver.NewVersion(b.TagName)
+
+		switch {
+		case errA != nil && errB != nil:
+			return 0
+		case errA != nil:
+			return 1
+		case errB != nil:
+			return -1
+		default:
+			return vb.Compare(va)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package downloader
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+const (
+	// CAFileEnvVar is the environment variable holding an extra PEM CA bundle to trust.
+	CAFileEnvVar = "GLINT_VM_CA_FILE"
+	// ClientCertEnvVar is the environment variable holding the PEM client certificate for mTLS mirrors.
+	ClientCertEnvVar = "GLINT_VM_CLIENT_CERT"
+	// ClientKeyEnvVar is the environment variable holding the PEM private key of the client certificate.
+	ClientKeyEnvVar = "GLINT_VM_CLIENT_KEY"
+)
+
+// TLSOptions configures TLS for every HTTP request made by the downloader.
+type TLSOptions struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots, e.g. for a TLS-intercepting proxy.
+	CAFile string
+	// ClientCert and ClientKey are the PEM client certificate and key presented to mTLS mirrors.
+	ClientCert string
+	ClientKey  string
+}
+
+// newHTTPClient creates the HTTP client shared by all downloader requests.
+// Proxies are taken from HTTPS_PROXY, HTTP_PROXY and NO_PROXY. Its timeout fits archive
+// downloads; metadata requests are bounded by metadataTimeout through their context.
+func newHTTPClient(opts TLSOptions) (*http.Client, error) {
+	tlsConfig, err := opts.config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   downloadTimeout,
+	}, nil
+}
+
+// config builds the TLS configuration, or nil to use Go's defaults.
+func (o TLSOptions) config() (*tls.Config, error) {
+	if o.CAFile == "" && o.ClientCert == "" && o.ClientKey == "" {
+		return nil, nil //nolint:nilnil // Default TLS configuration
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if o.CAFile != "" {
+		pool, err := loadCertPool(o.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if o.ClientCert != "" || o.ClientKey != "" {
+		if o.ClientCert == "" || o.ClientKey == "" {
+			return nil, fmt.Errorf("%w: both a certificate and a key are required", ErrInvalidClientCert)
+		}
+
+		cert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidClientCert, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadCertPool returns the system roots extended with the certificates of a PEM bundle.
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile) //nolint:gosec // CA file path comes from user configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		// No system roots available (e.g. minimal containers), trust the bundle only
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: no certificate found in %s", ErrInvalidCABundle, caFile)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,177 @@
+package downloader
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertPEM writes a certificate to a PEM file and returns its path.
+func writeCertPEM(t *testing.T, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "cert.pem")
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), filePermission); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	return path
+}
+
+// writeClientCert generates a self-signed client certificate and returns its certificate and key paths.
+func writeClientCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "glint-vm"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), filePermission); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	return writeCertPEM(t, der), keyPath
+}
+
+func TestNewHTTPClient_CAFile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr bool
+	}{
+		{name: "untrusted server", opts: TLSOptions{}, wantErr: true},
+		{name: "trusted through CA file", opts: TLSOptions{CAFile: writeCertPEM(t, server.Certificate().Raw)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := newHTTPClient(tt.opts)
+			if err != nil {
+				t.Fatalf("newHTTPClient() failed: %v", err)
+			}
+
+			dl := &Downloader{httpClient: client, retry: DefaultRetryPolicy()}
+
+			_, err = dl.fetchChecksumFile(context.Background(), server.URL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("fetchChecksumFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	certFile, keyFile := writeClientCert(t)
+
+	client, err := newHTTPClient(TLSOptions{
+		CAFile:     writeCertPEM(t, server.Certificate().Raw),
+		ClientCert: certFile,
+		ClientKey:  keyFile,
+	})
+	if err != nil {
+		t.Fatalf("newHTTPClient() failed: %v", err)
+	}
+
+	dl := &Downloader{httpClient: client, retry: RetryPolicy{Attempts: 1}.withDefaults()}
+
+	content, err := dl.fetchChecksumFile(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("fetchChecksumFile() failed: %v", err)
+	}
+
+	if string(content) != "ok" {
+		t.Errorf("fetchChecksumFile() = %q, want %q", content, "ok")
+	}
+}
+
+func TestNewHTTPClient_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	notPEM := filepath.Join(t.TempDir(), "bundle.pem")
+
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), filePermission); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	certFile, _ := writeClientCert(t)
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr error
+	}{
+		{name: "CA bundle without certificates", opts: TLSOptions{CAFile: notPEM}, wantErr: ErrInvalidCABundle},
+		{name: "certificate without key", opts: TLSOptions{ClientCert: certFile}, wantErr: ErrInvalidClientCert},
+		{name: "key is not a key", opts: TLSOptions{ClientCert: certFile, ClientKey: notPEM}, wantErr: ErrInvalidClientCert},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newHTTPClient(tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newHTTPClient() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
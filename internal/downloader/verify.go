@@ -0,0 +1,253 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+// smokeTestTimeout bounds the run of golangci-lint --version.
+const smokeTestTimeout = 30 * time.Second
+
+// verifyBinary checks that an installed binary is an executable of the target platform and,
+// when it can run on the host, that it reports the requested version.
+// goarch is the architecture of the installed asset, which differs from the target under emulation.
+func (d *Downloader) verifyBinary(ctx context.Context, binaryPath, version, goarch string) error {
+	goos := d.config.OS
+	goarch = binaryArch(goarch)
+
+	if err := checkBinaryFormat(binaryPath, goos, goarch); err != nil {
+		return err
+	}
+
+	// Binaries of other platforms, or run under emulation, are only checked statically
+	if goos != runtime.GOOS || goarch != runtime.GOARCH {
+		return nil
+	}
+
+	return runVersionCheck(ctx, binaryPath, version)
+}
+
+// binaryArch returns the GOARCH of an asset architecture, dropping the ARM version of armvN.
+func binaryArch(goarch string) string {
+	if strings.HasPrefix(goarch, "armv") {
+		return "arm"
+	}
+
+	return goarch
+}
+
+// checkBinaryFormat checks the executable header of a binary against the target OS and architecture:
+// PE on Windows, Mach-O (possibly universal) on macOS, ELF elsewhere.
+// The architecture isn't checked when its machine type is unknown.
+func checkBinaryFormat(binaryPath, goos, goarch string) error {
+	switch goos {
+	case "windows":
+		return checkPE(binaryPath, goarch)
+	case "darwin":
+		return checkMachO(binaryPath, goarch)
+	default:
+		return checkELF(binaryPath, goos, goarch)
+	}
+}
+
+func checkELF(binaryPath, goos, goarch string) error {
+	file, err := elf.Open(binaryPath)
+	if err != nil {
+		return fmt.Errorf("%w: not a valid %s ELF executable: %w", ErrBinaryMismatch, goos, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	if want, ok := elfMachine(goarch); ok && file.Machine != want {
+		return fmt.Errorf("%w: built for %s, want %s", ErrBinaryMismatch, file.Machine, goarch)
+	}
+
+	return nil
+}
+
+func checkMachO(binaryPath, goarch string) error {
+	want, known := machoCPU(goarch)
+
+	// Universal binaries hold one image per architecture
+	fat, err := macho.OpenFat(binaryPath)
+	if err == nil {
+		defer func() { _ = fat.Close() }()
+
+		cpus := make([]string, 0, len(fat.Arches))
+
+		for _, arch := range fat.Arches {
+			if !known || arch.Cpu == want {
+				return nil
+			}
+
+			cpus = append(cpus, arch.Cpu.String())
+		}
+
+		return fmt.Errorf("%w: universal binary for %s, want %s", ErrBinaryMismatch, strings.Join(cpus, ", "), goarch)
+	}
+
+	if !errors.Is(err, macho.ErrNotFat) {
+		return fmt.Errorf("%w: not a valid darwin Mach-O executable: %w", ErrBinaryMismatch, err)
+	}
+
+	file, err := macho.Open(binaryPath)
+	if err != nil {
+		return fmt.Errorf("%w: not a valid darwin Mach-O executable: %w", ErrBinaryMismatch, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	if known && file.Cpu != want {
+		return fmt.Errorf("%w: built for %s, want %s", ErrBinaryMismatch, file.Cpu, goarch)
+	}
+
+	return nil
+}
+
+func checkPE(binaryPath, goarch string) error {
+	file, err := pe.Open(binaryPath)
+	if err != nil {
+		return fmt.Errorf("%w: not a valid windows PE executable: %w", ErrBinaryMismatch, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	if want, ok := peMachine(goarch); ok && file.Machine != want {
+		return fmt.Errorf("%w: built for machine %#x, want %s", ErrBinaryMismatch, file.Machine, goarch)
+	}
+
+	return nil
+}
+
+func elfMachine(goarch string) (elf.Machine, bool) {
+	switch goarch {
+	case "386":
+		return elf.EM_386, true
+	case "amd64":
+		return elf.EM_X86_64, true
+	case "arm":
+		return elf.EM_ARM, true
+	case "arm64":
+		return elf.EM_AARCH64, true
+	case "loong64":
+		return elf.EM_LOONGARCH, true
+	case "mips", "mipsle", "mips64", "mips64le":
+		return elf.EM_MIPS, true
+	case "ppc64", "ppc64le":
+		return elf.EM_PPC64, true
+	case "riscv64":
+		return elf.EM_RISCV, true
+	case "s390x":
+		return elf.EM_S390, true
+	default:
+		return 0, false
+	}
+}
+
+func machoCPU(goarch string) (macho.Cpu, bool) {
+	switch goarch {
+	case "amd64":
+		return macho.CpuAmd64, true
+	case "arm64":
+		return macho.CpuArm64, true
+	default:
+		return 0, false
+	}
+}
+
+func peMachine(goarch string) (uint16, bool) {
+	switch goarch {
+	case "386":
+		return pe.IMAGE_FILE_MACHINE_I386, true
+	case "amd64":
+		return pe.IMAGE_FILE_MACHINE_AMD64, true
+	case "arm":
+		return pe.IMAGE_FILE_MACHINE_ARMNT, true
+	case "arm64":
+		return pe.IMAGE_FILE_MACHINE_ARM64, true
+	default:
+		return 0, false
+	}
+}
+
+// runVersionCheck runs golangci-lint --version and checks that it reports the requested version.
+// The binary runs in an empty temporary directory, with a minimal environment pointing its home
+// and cache there, so that no project or user configuration is loaded.
+func runVersionCheck(ctx context.Context, binaryPath, version string) error {
+	sandbox, err := os.MkdirTemp("", "glint-vm-smoke-")
+	if err != nil {
+		return fmt.Errorf("failed to create smoke test directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(sandbox) }()
+
+	ctx, cancel := context.WithTimeout(ctx, smokeTestTimeout)
+	defer cancel()
+
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, binaryPath, "--version") //nolint:gosec // Binary installed by glint-vm
+	cmd.Dir = sandbox
+	cmd.Env = sandboxEnv(sandbox)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s --version: %w: %s", ErrSmokeTestFailed, binaryPath, err, strings.TrimSpace(output.String()))
+	}
+
+	reported, ok := reportedVersion(output.String())
+	if !ok {
+		return fmt.Errorf("%w: unexpected --version output %q", ErrVersionMismatch, strings.TrimSpace(output.String()))
+	}
+
+	if reported != version {
+		return fmt.Errorf("%w: binary reports %s, want %s", ErrVersionMismatch, reported, version)
+	}
+
+	return nil
+}
+
+// reportedVersion extracts the version from the output of golangci-lint --version,
+// e.g. "golangci-lint has version 1.55.2 built with go1.21.3 from e3c2265f on 2023-11-03T12:59:25Z".
+func reportedVersion(output string) (string, bool) {
+	_, rest, found := strings.Cut(output, "has version ")
+	fields := strings.Fields(rest)
+
+	if !found || len(fields) == 0 {
+		return "", false
+	}
+
+	return config.NormalizeVersion(fields[0]), true
+}
+
+// sandboxEnv returns the environment of the smoke test, rooted in dir.
+func sandboxEnv(dir string) []string {
+	env := []string{
+		"HOME=" + dir,
+		"USERPROFILE=" + dir,
+		"TMPDIR=" + dir,
+		"XDG_CACHE_HOME=" + dir,
+		"XDG_CONFIG_HOME=" + dir,
+		"GOLANGCI_LINT_CACHE=" + dir,
+	}
+
+	// Windows processes need it to load system libraries
+	if root := os.Getenv("SYSTEMROOT"); root != "" {
+		env = append(env, "SYSTEMROOT="+root)
+	}
+
+	return env
+}
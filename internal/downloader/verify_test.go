@@ -0,0 +1,179 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// otherArch returns an architecture the host cannot run natively.
+func otherArch() string {
+	if runtime.GOARCH == "arm64" {
+		return "amd64"
+	}
+
+	return "arm64"
+}
+
+func TestCheckBinaryFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		binary  []byte
+		goos    string
+		goarch  string
+		wantErr error
+	}{
+		{name: "host binary", binary: fakeBinary, goos: runtime.GOOS, goarch: runtime.GOARCH},
+		{name: "truncated host binary", binary: fakeBinary[:4096], goos: runtime.GOOS, goarch: runtime.GOARCH, wantErr: ErrBinaryMismatch},
+		{name: "not an executable", binary: []byte("#!/bin/sh\n"), goos: "linux", goarch: "amd64", wantErr: ErrBinaryMismatch},
+		{name: "linux arm", binary: fakeExecutable(t, "linux", "arm"), goos: "linux", goarch: "arm"},
+		{name: "linux wrong arch", binary: fakeExecutable(t, "linux", "arm64"), goos: "linux", goarch: "amd64", wantErr: ErrBinaryMismatch},
+		{name: "darwin amd64", binary: fakeExecutable(t, "darwin", "amd64"), goos: "darwin", goarch: "amd64"},
+		{name: "darwin wrong arch", binary: fakeExecutable(t, "darwin", "amd64"), goos: "darwin", goarch: "arm64", wantErr: ErrBinaryMismatch},
+		{name: "ELF for darwin", binary: fakeExecutable(t, "linux", "amd64"), goos: "darwin", goarch: "amd64", wantErr: ErrBinaryMismatch},
+		{name: "windows arm64", binary: fakeExecutable(t, "windows", "arm64"), goos: "windows", goarch: "arm64"},
+		{name: "windows wrong arch", binary: fakeExecutable(t, "windows", "arm64"), goos: "windows", goarch: "386", wantErr: ErrBinaryMismatch},
+		{name: "unknown arch", binary: fakeExecutable(t, "linux", "amd64"), goos: "freebsd", goarch: "sparc64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "golangci-lint")
+			if err := os.WriteFile(path, tt.binary, filePermission); err != nil {
+				t.Fatalf("failed to write binary: %v", err)
+			}
+
+			if err := checkBinaryFormat(path, tt.goos, tt.goarch); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkBinaryFormat() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunVersionCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		wantErr error
+	}{
+		{name: "matching version", version: testVersion},
+		{name: "other version", version: "v1.59.1", wantErr: ErrVersionMismatch},
+	}
+
+	// The fake linter reports the version of its directory
+	binaryPath := filepath.Join(t.TempDir(), testVersion, "golangci-lint")
+
+	if err := os.MkdirAll(filepath.Dir(binaryPath), directoryPermission); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	//nolint:gosec // Test binary must be executable
+	if err := os.WriteFile(binaryPath, fakeBinary, executablePermission); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := runVersionCheck(context.Background(), binaryPath, tt.version); !errors.Is(err, tt.wantErr) {
+				t.Errorf("runVersionCheck() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "v1 output",
+			output: "golangci-lint has version 1.55.2 built with go1.21.3 from e3c2265f on 2023-11-03T12:59:25Z\n",
+			want:   "v1.55.2",
+			wantOK: true,
+		},
+		{
+			name:   "prefixed version",
+			output: "golangci-lint has version v1.23.8 built from 63e9d5a on 2020-02-19T23:56:13Z\n",
+			want:   "v1.23.8",
+			wantOK: true,
+		},
+		{name: "unexpected output", output: "usage: golangci-lint [flags]\n"},
+		{name: "no version", output: "golangci-lint has version "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := reportedVersion(tt.output)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("reportedVersion() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDownload_RejectsMismatchedBinary(t *testing.T) {
+	archive := buildTarball(t, testVersion, fakeExecutable(t, runtime.GOOS, otherArch()))
+
+	server := newReleaseServer(t, map[string][]byte{
+		releaseAssetPath(testVersion):             archive,
+		releaseAssetPath(testVersion) + ".sha256": []byte(sha256Hex(archive)),
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+
+	if err := dl.Download(context.Background(), testVersion); !errors.Is(err, ErrBinaryMismatch) {
+		t.Fatalf("Download() error = %v, want %v", err, ErrBinaryMismatch)
+	}
+
+	if _, err := os.Stat(dl.cacheManager.GetVersionDir(testVersion)); !os.IsNotExist(err) {
+		t.Error("rejected version should not be installed")
+	}
+}
+
+func TestInstallFromFile_KeepsInstalledVersion(t *testing.T) {
+	dl := newTestDownloader(t, Options{})
+
+	good := filepath.Join(t.TempDir(), "golangci-lint")
+	if err := os.WriteFile(good, fakeBinary, filePermission); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+
+	if err := dl.InstallFromFile(context.Background(), good, testVersion, ""); err != nil {
+		t.Fatalf("InstallFromFile() failed: %v", err)
+	}
+
+	// A reinstall with a binary of another platform fails verification
+	bad := filepath.Join(t.TempDir(), "golangci-lint")
+	if err := os.WriteFile(bad, fakeExecutable(t, runtime.GOOS, otherArch()), filePermission); err != nil {
+		t.Fatalf("failed to write binary: %v", err)
+	}
+
+	if err := dl.InstallFromFile(context.Background(), bad, testVersion, ""); !errors.Is(err, ErrBinaryMismatch) {
+		t.Fatalf("InstallFromFile() error = %v, want %v", err, ErrBinaryMismatch)
+	}
+
+	assertFileContent(t, dl.cacheManager.GetBinaryPath(testVersion), fakeBinary)
+}
@@ -0,0 +1,66 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDownload_WindowsZip(t *testing.T) {
+	binary := fakeExecutable(t, "windows", runtime.GOARCH)
+	archive := buildZip(t, testVersion, runtime.GOARCH, binary)
+	assetPath := "/" + testVersion + "/golangci-lint-" + strings.TrimPrefix(testVersion, "v") + "-windows-" + runtime.GOARCH + ".zip"
+
+	server := newReleaseServer(t, map[string][]byte{
+		assetPath:             archive,
+		assetPath + ".sha256": []byte(sha256Hex(archive)),
+	})
+
+	mirrors, err := ParseMirrors([]string{server.URL})
+	if err != nil {
+		t.Fatalf("ParseMirrors() failed: %v", err)
+	}
+
+	dl := newTestDownloader(t, Options{Mirrors: mirrors})
+	dl.config.OS = "windows"
+
+	if err := dl.Download(context.Background(), testVersion); err != nil {
+		t.Fatalf("Download() failed: %v", err)
+	}
+
+	binaryPath := dl.cacheManager.GetBinaryPath(testVersion)
+	if !strings.HasSuffix(binaryPath, "golangci-lint.exe") {
+		t.Fatalf("GetBinaryPath() = %s, want golangci-lint.exe", binaryPath)
+	}
+
+	assertFileContent(t, binaryPath, binary)
+
+	if _, err := os.Stat(filepath.Join(dl.cacheManager.GetVersionDir(testVersion), "archive.zip")); !os.IsNotExist(err) {
+		t.Error("archive should be removed after extraction")
+	}
+}
+
+func TestExtractZip_NoBinary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "archive.zip")
+
+	archive := buildZip(t, testVersion, "amd64", fakeBinary)
+	// Corrupt the binary name so the archive contains no golangci-lint entry.
+	archive = []byte(strings.ReplaceAll(string(archive), "golangci-lint.exe", "golangci-lint.txt"))
+
+	if err := os.WriteFile(archivePath, archive, filePermission); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	dl := &Downloader{}
+
+	if err := dl.extractZip(archivePath, dir, archiveBinaryPath(testVersion, "windows-amd64", "golangci-lint.exe")); !errors.Is(err, ErrBinaryNotFound) {
+		t.Errorf("extractZip() error = %v, want %v", err, ErrBinaryNotFound)
+	}
+}
@@ -0,0 +1,12 @@
+package lockfile
+
+import "errors"
+
+// Lockfile-related errors.
+var (
+	// ErrNotFound is returned when the project has no lockfile.
+	ErrNotFound = errors.New("lockfile not found")
+
+	// ErrInvalid is returned when the lockfile can't be parsed.
+	ErrInvalid = errors.New("invalid lockfile")
+)
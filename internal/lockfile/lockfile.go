@@ -0,0 +1,98 @@
+// Package lockfile reads and writes .golangci-lint.lock files, which pin a
+// golangci-lint version and the SHA-256 of its release archive for each platform.
+package lockfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// FileName is the name of the lockfile in the project directory.
+	FileName = ".golangci-lint.lock"
+
+	filePermission os.FileMode = 0o644
+)
+
+// Lockfile pins a golangci-lint version and its archive checksums.
+type Lockfile struct {
+	// Version is the pinned golangci-lint version (e.g. v1.55.2).
+	Version string `json:"version"`
+	// Checksums maps a platform (e.g. linux-amd64) to the SHA-256 of its release archive.
+	Checksums map[string]string `json:"checksums"`
+}
+
+// DefaultPlatforms returns the platforms recorded by default in a lockfile.
+func DefaultPlatforms() []string {
+	return []string{"darwin-amd64", "darwin-arm64", "linux-amd64", "linux-arm64"}
+}
+
+// Path returns the lockfile path in a directory.
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load reads the lockfile from a directory.
+// Returns ErrNotFound if the directory has no lockfile.
+func Load(dir string) (*Lockfile, error) {
+	path := Path(dir)
+
+	content, err := os.ReadFile(path) //nolint:gosec // Path is constructed internally
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	var lock Lockfile
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", path, ErrInvalid, err)
+	}
+
+	if lock.Version == "" {
+		return nil, fmt.Errorf("%s: %w: missing version", path, ErrInvalid)
+	}
+
+	return &lock, nil
+}
+
+// Save writes the lockfile to a directory.
+func (l *Lockfile) Save(dir string) error {
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+
+	content = append(content, '\n')
+
+	if err := os.WriteFile(Path(dir), content, filePermission); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// Checksum returns the pinned archive checksum for a platform.
+func (l *Lockfile) Checksum(platform string) (string, bool) {
+	checksum, ok := l.Checksums[platform]
+
+	return checksum, ok && checksum != ""
+}
+
+// Platforms returns the locked platforms in sorted order.
+func (l *Lockfile) Platforms() []string {
+	platforms := make([]string, 0, len(l.Checksums))
+	for platform := range l.Checksums {
+		platforms = append(platforms, platform)
+	}
+
+	sort.Strings(platforms)
+
+	return platforms
+}
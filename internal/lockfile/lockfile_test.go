@@ -0,0 +1,79 @@
+package lockfile
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	lock := &Lockfile{
+		Version: "v1.55.2",
+		Checksums: map[string]string{
+			"linux-amd64":  "aaaa",
+			"darwin-arm64": "bbbb",
+		},
+	}
+
+	if err := lock.Save(dir); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, lock) {
+		t.Errorf("Load() = %+v, want %+v", got, lock)
+	}
+
+	if checksum, ok := got.Checksum("linux-amd64"); !ok || checksum != "aaaa" {
+		t.Errorf("Checksum(linux-amd64) = %q, %v, want %q, true", checksum, ok, "aaaa")
+	}
+
+	if _, ok := got.Checksum("linux-arm64"); ok {
+		t.Error("Checksum(linux-arm64) should not be found")
+	}
+
+	if platforms := got.Platforms(); !reflect.DeepEqual(platforms, []string{"darwin-arm64", "linux-amd64"}) {
+		t.Errorf("Platforms() = %v", platforms)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{name: "missing file", wantErr: ErrNotFound},
+		{name: "invalid JSON", content: "version: v1.55.2", wantErr: ErrInvalid},
+		{name: "missing version", content: `{"checksums": {}}`, wantErr: ErrInvalid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			if test.content != "" {
+				if err := os.WriteFile(Path(dir), []byte(test.content), 0o600); err != nil {
+					t.Fatalf("failed to write lockfile: %v", err)
+				}
+			}
+
+			if _, err := Load(dir); !errors.Is(err, test.wantErr) {
+				t.Errorf("Load() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
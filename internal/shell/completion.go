@@ -0,0 +1,74 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+// completionTimeout bounds the generation of a completion script.
+const completionTimeout = 30 * time.Second
+
+// SupportedShells returns the shells glint-vm integrates with.
+func SupportedShells() []string {
+	return []string{bashShell, zshShell}
+}
+
+// GenerateCompletion runs the completion subcommand of a golangci-lint binary for a shell,
+// and returns the script it prints.
+func GenerateCompletion(ctx context.Context, binaryPath, shellName string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, binaryPath, "completion", shellName) //nolint:gosec // Binary installed by glint-vm
+	// Run outside the project so that its configuration isn't loaded
+	cmd.Dir = os.TempDir()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// A binary that ran and failed on its own has no completion command, unlike one that timed out
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			err = fmt.Errorf("%w: %w", ErrCompletionUnsupported, err)
+		}
+
+		return nil, fmt.Errorf("%s completion %s: %w: %s", filepath.Base(binaryPath), shellName, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// generateCompletionLoader returns the code sourcing the golangci-lint completion of the current version.
+func generateCompletionLoader(shellName string) string {
+	cfg, err := config.New()
+	if err != nil {
+		// Without a cache directory, there is no completion to load
+		return ""
+	}
+
+	completionPath := cfg.GetCurrentCompletionPath(shellName)
+
+	// zsh completions register through compdef, only defined once compinit has run
+	condition := fmt.Sprintf("[[ -s %q ]]", completionPath)
+	if shellName == zshShell {
+		condition = "(( $+functions[compdef] )) && " + condition
+	}
+
+	return fmt.Sprintf(`
+# Load the golangci-lint completion of the active version
+if %s; then
+  source %q
+fi
+`, condition, completionPath)
+}
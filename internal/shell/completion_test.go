@@ -0,0 +1,81 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/youkoulayley/glint-vm/internal/config"
+)
+
+// currentCompletionPath returns the path the completion loader sources for a shell.
+func currentCompletionPath(t *testing.T, shellName string) string {
+	t.Helper()
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	return cfg.GetCurrentCompletionPath(shellName)
+}
+
+func TestGenerateCompletion(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping shell script binary on Windows")
+	}
+
+	tests := []struct {
+		name    string
+		script  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "completion command",
+			script: "#!/bin/sh\necho \"# $1 for $2\"\n",
+			want:   "# completion for bash\n",
+		},
+		{
+			name:    "no completion command",
+			script:  "#!/bin/sh\necho 'unknown command \"completion\"' >&2\nexit 3\n",
+			wantErr: ErrCompletionUnsupported,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			binaryPath := filepath.Join(t.TempDir(), "golangci-lint")
+
+			//nolint:gosec // Test binary must be executable
+			if err := os.WriteFile(binaryPath, []byte(test.script), 0o755); err != nil {
+				t.Fatalf("Failed to write binary: %v", err)
+			}
+
+			got, err := GenerateCompletion(context.Background(), binaryPath, bash)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("GenerateCompletion() error = %v, want %v", err, test.wantErr)
+			}
+
+			if string(got) != test.want {
+				t.Errorf("GenerateCompletion() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCompletion_MissingBinary(t *testing.T) {
+	t.Parallel()
+
+	_, err := GenerateCompletion(context.Background(), filepath.Join(t.TempDir(), "golangci-lint"), bash)
+	if err == nil || errors.Is(err, ErrCompletionUnsupported) {
+		t.Errorf("GenerateCompletion() error = %v, want a failure other than %v", err, ErrCompletionUnsupported)
+	}
+}
@@ -6,4 +6,7 @@ import "errors"
 var (
 	// ErrUnsupportedShell is returned when an unsupported shell is requested.
 	ErrUnsupportedShell = errors.New("unsupported shell (supported: bash, zsh)")
+
+	// ErrCompletionUnsupported is returned when a golangci-lint binary has no completion command.
+	ErrCompletionUnsupported = errors.New("completion not supported")
 )
@@ -213,6 +213,7 @@ func TestBashShell_GenerateInit(t *testing.T) {
 				"_glint_vm_auto_switch",
 				"PROMPT_COMMAND",
 				"GLINT_VM_ROOT}/versions/",
+				"golangci-lint.bash",
 				"_GLINT_VM_NOTIFIED_VERSION",
 			},
 			wantExcludes: []string{},
@@ -252,6 +253,7 @@ func TestBashShell_GenerateUse(t *testing.T) {
 		"export PATH=",
 		"current:",
 		"export GLINT_VM_VERSION=\"v1.55.2\"",
+		"source \"" + currentCompletionPath(t, bash) + "\"",
 		"echo \"Switched to golangci-lint v1.55.2\" >&2",
 	}
 
@@ -311,6 +313,8 @@ func TestZshShell_GenerateInit(t *testing.T) {
 				"chpwd",
 				"add-zsh-hook",
 				"GLINT_VM_ROOT}/versions/",
+				"$+functions[compdef]",
+				"golangci-lint.zsh",
 				"_GLINT_VM_NOTIFIED_VERSION",
 			},
 			wantExcludes: []string{
@@ -352,6 +356,7 @@ func TestZshShell_GenerateUse(t *testing.T) {
 		"export PATH=",
 		"current:",
 		"export GLINT_VM_VERSION=\"v1.54.0\"",
+		"source \"" + currentCompletionPath(t, zsh) + "\"",
 		"echo \"Switched to golangci-lint v1.54.0\" >&2",
 	}
 
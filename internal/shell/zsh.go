@@ -31,6 +31,9 @@ func (z *ZshShell) GenerateInit(opts InitOptions) string {
 	// Add wrapper function that auto-evals commands that modify the environment
 	builder.WriteString(generateWrapperFunction())
 
+	// Load the completion of the current version
+	builder.WriteString(generateCompletionLoader(zshShell))
+
 	// Add auto-switch hook if requested
 	if opts.AutoSwitch {
 		builder.WriteString(generateZshAutoSwitch())
@@ -51,6 +54,9 @@ func (z *ZshShell) GenerateUse(version string) string {
 	// Export GLINT_VM_VERSION
 	fmt.Fprintf(&builder, "export GLINT_VM_VERSION=%q\n", version)
 
+	// Reload the completion, matching the flags of the new version
+	builder.WriteString(generateCompletionLoader(zshShell))
+
 	// Echo success message to stderr (so stdout is clean for eval)
 	fmt.Fprintf(&builder, "echo \"Switched to golangci-lint %s\" >&2\n", version)
 